@@ -11,19 +11,24 @@ package apidoc
 
 import (
 	"bytes"
+	"encoding/xml"
 	"mime"
 	"net/http"
 	"path/filepath"
 
+	"github.com/issue9/version"
 	"golang.org/x/text/language"
 
+	"github.com/caixw/apidoc/v6/diff"
 	"github.com/caixw/apidoc/v6/doc"
 	"github.com/caixw/apidoc/v6/input"
 	"github.com/caixw/apidoc/v6/internal/docs"
 	"github.com/caixw/apidoc/v6/internal/locale"
 	"github.com/caixw/apidoc/v6/internal/mock"
+	"github.com/caixw/apidoc/v6/internal/openapi"
 	xpath "github.com/caixw/apidoc/v6/internal/path"
 	"github.com/caixw/apidoc/v6/internal/vars"
+	"github.com/caixw/apidoc/v6/lint"
 	"github.com/caixw/apidoc/v6/message"
 	"github.com/caixw/apidoc/v6/output"
 )
@@ -75,11 +80,213 @@ func Buffer(h *message.Handler, o *output.Options, i ...*input.Options) (*bytes.
 
 // Test 测试文档语法，并将结果输出到 h
 func Test(h *message.Handler, i ...*input.Options) {
+	erro := h.Count(message.Erro)
+
 	if _, err := input.Parse(h, i...); err != nil {
 		h.Error(message.Erro, err)
 		return
 	}
-	h.Message(message.Succ, locale.TestSuccess)
+
+	if h.Count(message.Erro) == erro { // 解析过程中的错误都直接输出到了 h
+		h.Message(message.Succ, locale.TestSuccess)
+	}
+}
+
+// Lint 按 o 指定的规则检测文档的风格，并将结果输出到 h
+//
+// 文档本身存在语法错误时，不会再进行风格的检测。
+// o 为 nil 表示所有规则都采用默认的级别。
+func Lint(h *message.Handler, o *lint.Options, i ...*input.Options) {
+	erro := h.Count(message.Erro)
+
+	d, err := input.Parse(h, i...)
+	if err != nil {
+		h.Error(message.Erro, err)
+		return
+	}
+	if h.Count(message.Erro) > erro {
+		return
+	}
+
+	count := h.Count(message.Erro) + h.Count(message.Warn) + h.Count(message.Info)
+	if err := lint.Check(h, d, o); err != nil {
+		h.Error(message.Erro, err)
+		return
+	}
+
+	if h.Count(message.Erro)+h.Count(message.Warn)+h.Count(message.Info) == count {
+		h.Message(message.Succ, locale.LintSuccess)
+	}
+}
+
+// Diff 比较两个文档之间的差别，并将结果输出到 h
+//
+// from 和 to 分别为旧文档和新文档的路径，可以是本地路径也可以是 URL。
+// 破坏性的改动以 message.Erro 输出，其它改动以 message.Info 输出，
+// 最后会根据改动的内容给出新文档版本号的建议。
+func Diff(h *message.Handler, from, to string) {
+	fromDoc, err := loadDoc(from)
+	if err != nil {
+		h.Error(message.Erro, err)
+		return
+	}
+
+	toDoc, err := loadDoc(to)
+	if err != nil {
+		h.Error(message.Erro, err)
+		return
+	}
+
+	changes := diff.Diff(fromDoc, toDoc)
+	if len(changes) == 0 {
+		h.Message(message.Succ, locale.DiffNoChanges)
+		return
+	}
+
+	for _, c := range changes {
+		t := message.Info
+		if c.Breaking {
+			t = message.Erro
+		}
+		h.Message(t, locale.DiffMessage, c.Location(), c.Description())
+	}
+
+	if fromDoc.Version == "" {
+		return
+	}
+
+	v, err := changes.Version(fromDoc.Version)
+	if err != nil {
+		h.Error(message.Erro, err)
+		return
+	}
+	h.Message(message.Info, locale.DiffSuggestVersion, v)
+
+	if toDoc.Version != "" {
+		if ret, err := version.SemVerCompare(string(toDoc.Version), string(v)); err == nil && ret < 0 {
+			h.Message(message.Warn, locale.DiffVersionTooLow, toDoc.Version, v)
+		}
+	}
+}
+
+// 更新日志的格式
+const (
+	ChangelogMarkdown = "markdown"
+	ChangelogRichtext = "richtext" // 以 apidoc 中 description 元素的形式输出，内容为 markdown。
+)
+
+// Changelog 根据两个文档之间的差别生成更新日志
+//
+// from 和 to 分别为旧文档和新文档的路径，可以是本地路径也可以是 URL；
+// typ 为更新日志的格式，可以是 ChangelogMarkdown 或是 ChangelogRichtext。
+func Changelog(from, to, typ string) (*bytes.Buffer, error) {
+	if typ != ChangelogMarkdown && typ != ChangelogRichtext {
+		return nil, message.NewLocaleError("", "type", 0, locale.ErrInvalidValue)
+	}
+
+	fromDoc, err := loadDoc(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toDoc, err := loadDoc(to)
+	if err != nil {
+		return nil, err
+	}
+
+	data := diff.Changelog(fromDoc, toDoc)
+	if typ == ChangelogMarkdown {
+		return bytes.NewBuffer(data), nil
+	}
+
+	buf := new(bytes.Buffer)
+	e := xml.NewEncoder(buf)
+	text := doc.Richtext{Type: doc.RichtextTypeMarkdown, Text: string(data)}
+	if err := e.EncodeElement(text, xml.StartElement{Name: xml.Name{Local: "description"}}); err != nil {
+		return nil, err
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// 导入的格式
+const (
+	ImportDoc = "doc" // 完整的 apidoc 文档
+	ImportAPI = "api" // 每个 API 单独输出，方便复制到代码注释中。
+)
+
+// Import 将 OpenAPI 3 文档转换成 apidoc 的 XML 格式
+//
+// path 为 OpenAPI 文档的路径，可以是本地路径也可以是 URL，支持 JSON 和 YAML 格式；
+// typ 为导入的格式，可以是 ImportDoc 或是 ImportAPI。
+// 无法转换的内容会被忽略，并以警告的形式输出到 h。
+func Import(h *message.Handler, path, typ string) (*bytes.Buffer, error) {
+	if typ != ImportDoc && typ != ImportAPI {
+		return nil, message.NewLocaleError("", "type", 0, locale.ErrInvalidValue)
+	}
+
+	data, err := xpath.ReadFile(path)
+	if err != nil {
+		return nil, message.WithError(path, "", 0, err)
+	}
+
+	oa, err := openapi.Unmarshal(data)
+	if serr, ok := err.(*message.SyntaxError); ok {
+		serr.File = path
+		return nil, serr
+	} else if err != nil {
+		return nil, message.WithError(path, "", 0, err)
+	}
+
+	d, warns := openapi.Import(oa)
+	for _, w := range warns {
+		w.File = path
+		h.Error(message.Warn, w)
+	}
+
+	var apis []*doc.API
+	if typ == ImportAPI {
+		apis = d.Apis
+		d.Apis = nil
+	}
+
+	data, err = xml.MarshalIndent(d, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(data)
+	buf.WriteByte('\n')
+
+	for _, api := range apis {
+		if data, err = xml.MarshalIndent(api, "", "\t"); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	return buf, nil
+}
+
+func loadDoc(path string) (*doc.Doc, error) {
+	data, err := xpath.ReadFile(path)
+	if err != nil {
+		return nil, message.WithError(path, "", 0, err)
+	}
+
+	d := doc.New()
+	if err = d.FromXML(path, 0, data); err != nil {
+		return nil, err
+	}
+	if err = d.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
 }
 
 // Static 为 /docs 搭建一个静态文件服务
@@ -167,6 +374,9 @@ func MockBuffer(h *message.Handler, data []byte, servers map[string]string) (htt
 	if err := d.FromXML("", 0, data); err != nil {
 		return nil, err
 	}
+	if err := d.Sanitize(); err != nil {
+		return nil, err
+	}
 
 	return Mock(h, d, servers)
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/caixw/apidoc/v6/internal/locale"
 	"github.com/caixw/apidoc/v6/internal/path"
 	"github.com/caixw/apidoc/v6/internal/vars"
+	"github.com/caixw/apidoc/v6/lint"
 	"github.com/caixw/apidoc/v6/message"
 	"github.com/caixw/apidoc/v6/output"
 )
@@ -35,7 +36,18 @@ type Config struct {
 	Inputs []*input.Options `yaml:"inputs"`
 
 	// 输出配置项
-	Output *output.Options `yaml:"output"`
+	//
+	// 与 Outputs 至少需要指定一个。
+	Output *output.Options `yaml:"output,omitempty"`
+
+	// 多个输出配置项
+	//
+	// 文档只会解析一次，之后依次输出到每一个目标，
+	// 各个目标的过滤条件互不影响。
+	Outputs []*output.Options `yaml:"outputs,omitempty"`
+
+	// lint 子命令的配置项，可以为空。
+	Linter *lint.Options `yaml:"lint,omitempty"`
 
 	// 配置文件所在的目录
 	//
@@ -100,7 +112,7 @@ func (cfg *Config) sanitize(file string) *message.SyntaxError {
 		return message.NewLocaleError(file, "inputs", 0, locale.ErrRequired)
 	}
 
-	if cfg.Output == nil {
+	if cfg.Output == nil && len(cfg.Outputs) == 0 {
 		return message.NewLocaleError(file, "output", 0, locale.ErrRequired)
 	}
 
@@ -112,13 +124,35 @@ func (cfg *Config) sanitize(file string) *message.SyntaxError {
 		}
 	}
 
-	if cfg.Output.Path, err = path.Abs(cfg.Output.Path, cfg.wd); err != nil {
-		return message.WithError(file, "output.path", 0, err)
+	if cfg.Output != nil {
+		if cfg.Output.Path, err = path.Abs(cfg.Output.Path, cfg.wd); err != nil {
+			return message.WithError(file, "output.path", 0, err)
+		}
+	}
+
+	for index, o := range cfg.Outputs {
+		field := "outputs[" + strconv.Itoa(index) + "]"
+
+		if o == nil {
+			return message.NewLocaleError(file, field, 0, locale.ErrRequired)
+		}
+
+		if o.Path, err = path.Abs(o.Path, cfg.wd); err != nil {
+			return message.WithError(file, field+".path", 0, err)
+		}
 	}
 
 	return nil
 }
 
+// 所有的输出配置项，Output 排在最前面。
+func (cfg *Config) outputs() []*output.Options {
+	if cfg.Output == nil {
+		return cfg.Outputs
+	}
+	return append([]*output.Options{cfg.Output}, cfg.Outputs...)
+}
+
 func detectConfig(wd string, recursive bool) (*Config, error) {
 	inputs, err := input.Detect(wd, recursive)
 	if err != nil {
@@ -164,21 +198,32 @@ func Detect(wd string, recursive bool) error {
 
 // Build 解析文档并输出文档内容
 //
+// 文档只解析一次，之后分别输出到 Output 和 Outputs 指定的每一个目标，
+// 某一个目标输出失败，不影响其它目标。
 // 具体信息可参考 Build 函数的相关文档。
 func (cfg *Config) Build(start time.Time) {
-	if err := Build(cfg.h, cfg.Output, cfg.Inputs...); err != nil {
+	d, err := input.Parse(cfg.h, cfg.Inputs...)
+	if err != nil {
 		cfg.h.Error(message.Erro, err)
 		return
 	}
 
-	cfg.h.Message(message.Succ, locale.Complete, cfg.Output.Path, time.Now().Sub(start))
+	for _, o := range cfg.outputs() {
+		if err := output.Render(d.Clone(), o); err != nil {
+			cfg.h.Error(message.Erro, err)
+			continue
+		}
+
+		cfg.h.Message(message.Succ, locale.Complete, o.Path, time.Now().Sub(start))
+	}
 }
 
 // Buffer 根据 wd 目录下的配置文件生成文档内容并保存至内存
 //
+// 存在多个输出配置项时，仅使用第一个。
 // 具体信息可参考 Buffer 函数的相关文档。
 func (cfg *Config) Buffer() *bytes.Buffer {
-	buf, err := Buffer(cfg.h, cfg.Output, cfg.Inputs...)
+	buf, err := Buffer(cfg.h, cfg.outputs()[0], cfg.Inputs...)
 	if err != nil {
 		cfg.h.Error(message.Erro, err)
 		return nil
@@ -191,3 +236,8 @@ func (cfg *Config) Buffer() *bytes.Buffer {
 func (cfg *Config) Test() {
 	Test(cfg.h, cfg.Inputs...)
 }
+
+// Lint 执行对文档风格的检测
+func (cfg *Config) Lint() {
+	Lint(cfg.h, cfg.Linter, cfg.Inputs...)
+}
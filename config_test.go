@@ -3,6 +3,8 @@
 package apidoc
 
 import (
+	"bytes"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -14,6 +16,7 @@ import (
 	"github.com/caixw/apidoc/v6/internal/docs"
 	"github.com/caixw/apidoc/v6/internal/vars"
 	"github.com/caixw/apidoc/v6/message/messagetest"
+	"github.com/caixw/apidoc/v6/output"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -91,6 +94,24 @@ func TestConfig_sanitize(t *testing.T) {
 	err = conf.sanitize("./apidoc.yaml")
 	a.Error(err).
 		Equal(err.Field, "output")
+
+	// outputs 中包含空值
+	conf.Outputs = []*output.Options{{Path: "./apidoc.xml"}, nil}
+	err = conf.sanitize("./apidoc.yaml")
+	a.Error(err).
+		Equal(err.Field, "outputs[1]")
+
+	// 仅声明 outputs
+	conf.Outputs = []*output.Options{{Path: "./apidoc.xml"}, {Path: "./openapi.json"}}
+	a.NotError(conf.sanitize("./apidoc.yaml"))
+	a.True(filepath.IsAbs(conf.Outputs[0].Path)).
+		True(filepath.IsAbs(conf.Outputs[1].Path)).
+		Equal(2, len(conf.outputs()))
+
+	conf.Output = &output.Options{Path: "./output.xml"}
+	a.NotError(conf.sanitize("./apidoc.yaml"))
+	a.Equal(3, len(conf.outputs())).
+		Equal(conf.outputs()[0], conf.Output)
 }
 
 func TestConfig_Test(t *testing.T) {
@@ -119,6 +140,33 @@ func TestConfig_Do(t *testing.T) {
 					Empty(erro.String())
 }
 
+func TestConfig_Build_outputs(t *testing.T) {
+	a := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "apidoc")
+	a.NotError(err)
+	defer os.RemoveAll(dir)
+
+	erro, succ, h := messagetest.MessageHandler()
+	cfg := LoadConfig(h, docs.Path("example"))
+	a.NotNil(cfg)
+	cfg.Output = nil
+	cfg.Outputs = []*output.Options{
+		{Path: filepath.Join(dir, "all.xml")},
+		{Path: filepath.Join(dir, "none.xml"), Tags: []string{"not-exists"}},
+		{Path: filepath.Join(dir, "all2.xml")},
+	}
+	cfg.Build(time.Now())
+	h.Stop()
+	a.Empty(erro.String()).NotEmpty(succ.String())
+
+	// 前一个目标的过滤条件不影响之后的目标
+	for index, contains := range []bool{true, false, true} {
+		data, err := ioutil.ReadFile(cfg.Outputs[index].Path)
+		a.NotError(err).Equal(bytes.Contains(data, []byte("<api ")), contains)
+	}
+}
+
 func TestConfig_Buffer(t *testing.T) {
 	a := assert.New(t)
 
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: MIT
+
+package diff
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/internal/locale"
+)
+
+// 更新日志中每个标签下的分组
+type section struct {
+	added      []string
+	changed    []string
+	deprecated []string
+	removed    []string
+}
+
+// Changelog 根据从 from 到 to 的改动生成 Markdown 格式的更新日志
+//
+// 改动按 API 的标签进行分组，同一个 API 有多个标签时，会出现在每一个标签中；
+// 没有标签的 API 以及不属于某个 API 的改动，统一归类到最后的分组中。
+func Changelog(from, to *doc.Doc) []byte {
+	apis := make(map[string]*doc.API, len(from.Apis)+len(to.Apis))
+	for _, api := range from.Apis {
+		apis[apiName(api)] = api
+	}
+	for _, api := range to.Apis { // 同时存在的，以新文档为准。
+		apis[apiName(api)] = api
+	}
+
+	all := make([]*doc.Tag, 0, len(to.Tags)+len(from.Tags))
+	all = append(append(all, to.Tags...), from.Tags...)
+
+	tags := make([]*doc.Tag, 0, len(all))
+	sections := make(map[string]*section, len(all))
+	for _, tag := range all {
+		if _, found := sections[tag.Name]; !found {
+			tags = append(tags, tag)
+			sections[tag.Name] = &section{}
+		}
+	}
+	untagged := &section{}
+
+	for _, c := range Diff(from, to) {
+		api := apis[c.API]
+
+		item := changelogItem(c, api)
+		secs := make([]*section, 0, 1)
+		if api != nil {
+			for _, tag := range api.Tags {
+				if sec, found := sections[tag]; found {
+					secs = append(secs, sec)
+				}
+			}
+		}
+		if len(secs) == 0 {
+			secs = append(secs, untagged)
+		}
+
+		for _, sec := range secs {
+			switch {
+			case c.Kind == Deprecated:
+				sec.deprecated = append(sec.deprecated, item)
+			case c.Field == "" && c.Kind == Added:
+				sec.added = append(sec.added, item)
+			case c.Field == "" && c.Kind == Removed:
+				sec.removed = append(sec.removed, item)
+			default:
+				sec.changed = append(sec.changed, item)
+			}
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "# %s %s\n\n", to.Title, to.Version)
+	if from.Version != "" && to.Version != "" {
+		fmt.Fprintf(buf, "%s → %s\n\n", from.Version, to.Version)
+	}
+
+	empty := true
+	for _, tag := range tags {
+		empty = sections[tag.Name].write(buf, tag.Title) && empty
+	}
+	empty = untagged.write(buf, locale.Sprintf(locale.ChangelogUntagged)) && empty
+
+	if empty {
+		fmt.Fprintln(buf, locale.Sprintf(locale.DiffNoChanges))
+	}
+
+	return buf.Bytes()
+}
+
+// 将 sec 的内容写入 buf，返回值表示 sec 是否为空。
+func (sec *section) write(buf *bytes.Buffer, title string) bool {
+	if len(sec.added)+len(sec.changed)+len(sec.deprecated)+len(sec.removed) == 0 {
+		return true
+	}
+
+	fmt.Fprintf(buf, "## %s\n\n", title)
+	writeItems(buf, locale.DiffAdded, sec.added)
+	writeItems(buf, locale.ChangelogChanged, sec.changed)
+	writeItems(buf, locale.ChangelogDeprecated, sec.deprecated)
+	writeItems(buf, locale.DiffRemoved, sec.removed)
+	return false
+}
+
+func writeItems(buf *bytes.Buffer, key string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "### %s\n\n", locale.Sprintf(key))
+	for _, item := range items {
+		fmt.Fprintf(buf, "- %s\n", item)
+	}
+	buf.WriteByte('\n')
+}
+
+// 生成改动 c 在更新日志中的内容，api 为 c 所在的 API，可能为空。
+func changelogItem(c *Change, api *doc.API) string {
+	var item string
+	if c.Breaking {
+		item = "**" + locale.Sprintf(locale.ChangelogBreaking) + "** "
+	}
+
+	if c.API != "" {
+		item += "`" + c.API + "`"
+	}
+	if c.Field != "" {
+		if c.API != "" {
+			item += " "
+		}
+		item += "`" + c.Field + "`"
+	}
+
+	switch {
+	case c.Field == "" && c.Kind == Added:
+		if api.Summary != "" {
+			item += " " + api.Summary
+		}
+		if api.Version != "" {
+			item += " " + locale.Sprintf(locale.ChangelogVersion, api.Version)
+		}
+	case c.Field == "" && c.Kind == Removed:
+	default:
+		item += locale.Sprintf(locale.ChangelogSeparator) + c.Description()
+	}
+
+	return item
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: MIT
+
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+)
+
+func TestChangelog(t *testing.T) {
+	a := assert.New(t)
+
+	from := newDoc(a, fromDoc)
+	data := string(Changelog(from, from))
+	a.True(strings.HasPrefix(data, "# title 1.0.0\n\n")).
+		NotContains(data, "##")
+
+	tag := strings.NewReplacer(`<server name="admin"`, `<tag name="users" title="Users" /><server name="admin"`)
+	from = newDoc(a, tag.Replace(fromDoc))
+	to := newDoc(a, strings.Replace(tag.Replace(toDoc), `<api method="POST" summary="summary">`, `<api method="POST" summary="create user" version="1.1.0"><tag>users</tag>`, 1))
+
+	data = string(Changelog(from, to))
+	a.True(strings.HasPrefix(data, "# title 1.1.0\n\n1.0.0 → 1.1.0\n\n"))
+
+	// 带标签的 API 在标签的分组中，其它的在最后一个分组中。
+	users := strings.Index(data, "\n## Users")
+	others := strings.Index(data, "\n## ")
+	a.True(users >= 0).Equal(users, others)
+	others = strings.LastIndex(data, "\n## ")
+	a.True(others > users)
+
+	a.Contains(data[users:others], "- `POST /users` create user")
+	a.Contains(data[others:], "`GET /users/{uid}` `query.page`")
+	a.Contains(data[others:], "`DELETE /users/{id}`")
+
+	// 不能改写 to.Tags 底层数组中的内容
+	tags := make([]*doc.Tag, 1, 5)
+	tags[0] = to.Tags[0]
+	to.Tags = tags
+	from.Tags = append(from.Tags, &doc.Tag{Name: "old", Title: "Old"})
+	Changelog(from, to)
+	a.Equal(len(to.Tags), 1).Nil(to.Tags[:2][1])
+}
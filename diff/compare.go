@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: MIT
+
+package diff
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/caixw/apidoc/v6/doc"
+)
+
+// Diff 比较两个文档，返回从 from 到 to 的所有改动
+//
+// API 以服务、请求方法和路径作为唯一标记，路径中参数名称的变化不影响其匹配；
+// 拥有多个服务的 API，只要其中一个服务相同即被当作是同一个 API。
+// from 和 to 都应该是已经通过 Sanitize 检测的文档。
+func Diff(from, to *doc.Doc) Changes {
+	d := &differ{changes: make(Changes, 0, 10)}
+
+	d.requests("", "response", from.Responses, to.Responses, false)
+
+	apis := make(map[string]*doc.API, len(from.Apis))
+	for _, api := range from.Apis {
+		for _, key := range serverKeys(api) {
+			apis[key] = api
+		}
+	}
+
+	matched := make(map[*doc.API]bool, len(from.Apis))
+	for _, api := range to.Apis {
+		var old *doc.API
+		for _, key := range serverKeys(api) {
+			if o, found := apis[key]; found && !matched[o] {
+				old = o
+				break
+			}
+		}
+
+		if old == nil {
+			d.add(&Change{Kind: Added, API: apiName(api)})
+			continue
+		}
+		matched[old] = true
+		d.api(old, api)
+	}
+
+	for _, api := range from.Apis { // 按 from.Apis 的顺序输出
+		if !matched[api] {
+			d.add(&Change{Kind: Removed, Breaking: true, API: apiName(api)})
+		}
+	}
+
+	return d.changes
+}
+
+type differ struct {
+	changes Changes
+	current string // 当前正在比较的 API
+}
+
+func (d *differ) add(c *Change) {
+	if c.API == "" {
+		c.API = d.current
+	}
+	d.changes = append(d.changes, c)
+}
+
+func (d *differ) api(from, to *doc.API) {
+	d.current = apiName(to)
+	defer func() { d.current = "" }()
+
+	d.deprecated("", from.Deprecated, to.Deprecated)
+
+	// 路径参数由路径决定，名称的变化不影响客户端，只比较同名参数的类型。
+	for _, p := range to.Path.Params {
+		if old := findParam(from.Path.Params, p.Name); old != nil {
+			d.param("param."+p.Name, old, p, true)
+		}
+	}
+	d.params("query", from.Path.Queries, to.Path.Queries, true)
+	d.params("header", from.Headers, to.Headers, true)
+	d.params("cookie", from.Cookies, to.Cookies, true)
+	d.requests("request", "", from.Requests, to.Requests, true)
+	d.requests("", "response", from.Responses, to.Responses, false)
+
+	switch {
+	case from.Callback == nil && to.Callback != nil:
+		d.add(&Change{Kind: Added, Field: "callback"})
+	case from.Callback != nil && to.Callback == nil:
+		d.add(&Change{Kind: Removed, Breaking: true, Field: "callback"})
+	case from.Callback != nil && to.Callback != nil:
+		// 回调是由服务端发起的请求，请求和返回的角色正好相反。
+		d.deprecated("callback", from.Callback.Deprecated, to.Callback.Deprecated)
+		d.requests("callback.request", "", from.Callback.Requests, to.Callback.Requests, false)
+		d.requests("", "callback.response", from.Callback.Responses, to.Callback.Responses, true)
+	}
+}
+
+// 比较两组 request 或是 response 的内容
+//
+// 如果是 request，以 mimetype 匹配，reqPrefix 为字段前缀；
+// 如果是 response，以状态码和 mimetype 匹配，respPrefix 为字段前缀。
+// request 表示这些内容是否由客户端发送。
+func (d *differ) requests(reqPrefix, respPrefix string, from, to []*doc.Request, request bool) {
+	field := func(r *doc.Request) string {
+		if respPrefix == "" {
+			if r.Mimetype == "" {
+				return reqPrefix
+			}
+			return reqPrefix + "(" + r.Mimetype + ")"
+		}
+
+		key := strconv.Itoa(int(r.Status))
+		if r.Mimetype != "" {
+			key += "," + r.Mimetype
+		}
+		return respPrefix + "(" + key + ")"
+	}
+
+	olds := make(map[string]*doc.Request, len(from))
+	for _, r := range from {
+		olds[field(r)] = r
+	}
+
+	for _, r := range to {
+		f := field(r)
+		old, found := olds[f]
+		if !found {
+			d.add(&Change{Kind: Added, Field: f})
+			continue
+		}
+		delete(olds, f)
+
+		d.param(f, old.Param(), r.Param(), request)
+		d.params(f+".header", old.Headers, r.Headers, request)
+		d.params(f+".cookie", old.Cookies, r.Cookies, request)
+	}
+
+	for _, r := range from {
+		if f := field(r); olds[f] != nil {
+			d.add(&Change{Kind: Removed, Breaking: true, Field: f})
+		}
+	}
+}
+
+// 比较两组参数，request 表示参数是否由客户端发送。
+//
+// 客户端发送的参数，新增必须的参数是破坏性的改动；
+// 客户端接收的参数，删除参数是破坏性的改动。
+func (d *differ) params(prefix string, from, to []*doc.Param, request bool) {
+	for _, p := range to {
+		field := prefix + "." + p.Name
+		if old := findParam(from, p.Name); old != nil {
+			d.param(field, old, p, request)
+			continue
+		}
+		d.add(&Change{Kind: Added, Breaking: request && isRequired(p), Field: field})
+	}
+
+	for _, p := range from {
+		if findParam(to, p.Name) == nil {
+			d.add(&Change{Kind: Removed, Breaking: !request, Field: prefix + "." + p.Name})
+		}
+	}
+}
+
+func (d *differ) param(field string, from, to *doc.Param, request bool) {
+	d.deprecated(field, from.Deprecated, to.Deprecated)
+
+	if oldType, newType := typeName(from), typeName(to); oldType != newType {
+		d.add(&Change{Kind: TypeChanged, Breaking: true, Field: field, Old: oldType, New: newType})
+		return
+	}
+
+	// 客户端发送的参数，可用的枚举值减少是破坏性的；
+	// 客户端接收的参数，可能出现的枚举值增加是破坏性的。
+	for _, e := range to.Enums {
+		if findEnum(from.Enums, e.Value) == nil {
+			d.add(&Change{Kind: EnumAdded, Breaking: !request, Field: field, New: e.Value})
+		}
+	}
+	for _, e := range from.Enums {
+		if findEnum(to.Enums, e.Value) == nil {
+			d.add(&Change{Kind: EnumRemoved, Breaking: request, Field: field, Old: e.Value})
+		}
+	}
+
+	switch oldRequired, newRequired := isRequired(from), isRequired(to); {
+	case !oldRequired && newRequired:
+		d.add(&Change{Kind: BecameRequired, Breaking: request, Field: field})
+	case oldRequired && !newRequired:
+		d.add(&Change{Kind: BecameOptional, Breaking: !request, Field: field})
+	}
+
+	d.params(field, from.Items, to.Items, request)
+}
+
+func (d *differ) deprecated(field string, from, to doc.Version) {
+	if from == "" && to != "" {
+		d.add(&Change{Kind: Deprecated, Field: field, New: string(to)})
+	}
+}
+
+// 参数是否必须存在，带默认值的参数，客户端也可以不提交。
+func isRequired(p *doc.Param) bool {
+	return !p.Optional && p.Default == ""
+}
+
+func typeName(p *doc.Param) string {
+	name := string(p.Type)
+	if p.Array {
+		name += "[]"
+	}
+	return name
+}
+
+func findParam(params []*doc.Param, name string) *doc.Param {
+	for _, p := range params {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func findEnum(enums []*doc.Enum, value string) *doc.Enum {
+	for _, e := range enums {
+		if e.Value == value {
+			return e
+		}
+	}
+	return nil
+}
+
+func apiName(api *doc.API) string {
+	return string(api.Method) + " " + api.Path.Path
+}
+
+// 同一个 API 在其每个服务下的标记
+//
+// 不同服务下可以存在请求方法和路径都相同的 API，所以需要加上服务名称才能区分。
+func serverKeys(api *doc.API) []string {
+	key := apiKey(api)
+	if len(api.Servers) == 0 {
+		return []string{key}
+	}
+
+	keys := make([]string, 0, len(api.Servers))
+	for _, srv := range api.Servers {
+		keys = append(keys, srv+" "+key)
+	}
+	return keys
+}
+
+// 仅参数名称不同的路径被当作是同一个 API，与 doc.Doc.Sanitize 中判断重复 API 的规则相同。
+func apiKey(api *doc.API) string {
+	return strings.ToUpper(string(api.Method)) + " " + doc.RouteKey(api.Path.Path)
+}
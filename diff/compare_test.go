@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: MIT
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+)
+
+const fromDoc = `<apidoc version="1.0.0">
+	<title>title</title>
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<mimetype>application/json</mimetype>
+	<api method="GET" summary="summary">
+		<path path="/users/{id}">
+			<param name="id" type="number" summary="id" />
+			<query name="page" type="number" summary="page" optional="true" />
+			<query name="size" type="number" summary="size" />
+		</path>
+		<server>admin</server>
+		<response status="200" type="object" summary="summary">
+			<param name="name" type="string" summary="name" />
+			<param name="age" type="number" summary="age" />
+			<param name="sex" type="string" summary="sex">
+				<enum value="male" summary="male" />
+				<enum value="female" summary="female" />
+			</param>
+		</response>
+	</api>
+	<api method="DELETE" summary="summary">
+		<path path="/users/{id}">
+			<param name="id" type="number" summary="id" />
+		</path>
+		<server>admin</server>
+		<response status="204" type="string" summary="summary" />
+	</api>
+</apidoc>`
+
+const toDoc = `<apidoc version="1.1.0">
+	<title>title</title>
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<mimetype>application/json</mimetype>
+	<api method="GET" summary="summary" deprecated="2.0.0">
+		<path path="/users/{uid}">
+			<param name="uid" type="number" summary="id" />
+			<query name="page" type="number" summary="page" />
+			<query name="size" type="number" summary="size" default="10" />
+			<query name="sort" type="string" summary="sort" />
+		</path>
+		<server>admin</server>
+		<response status="200" type="object" summary="summary">
+			<param name="name" type="string" summary="name" />
+			<param name="sex" type="string" summary="sex">
+				<enum value="male" summary="male" />
+				<enum value="female" summary="female" />
+				<enum value="unknown" summary="unknown" />
+			</param>
+			<param name="created" type="string" summary="created" />
+		</response>
+	</api>
+	<api method="POST" summary="summary">
+		<path path="/users" />
+		<server>admin</server>
+		<response status="201" type="string" summary="summary" />
+	</api>
+</apidoc>`
+
+func newDoc(a *assert.Assertion, data string) *doc.Doc {
+	d := doc.New()
+	a.NotError(d.FromXML("doc.xml", 1, []byte(data)))
+	a.NotError(d.Sanitize())
+	return d
+}
+
+func TestDiff(t *testing.T) {
+	a := assert.New(t)
+
+	from := newDoc(a, fromDoc)
+	to := newDoc(a, toDoc)
+
+	changes := Diff(from, from)
+	a.Empty(changes)
+
+	changes = Diff(from, to)
+	a.Equal(changes, Changes{
+		{Kind: Added, API: "POST /users"},
+		{Kind: Deprecated, API: "GET /users/{uid}", New: "2.0.0"},
+		{Kind: BecameRequired, Breaking: true, API: "GET /users/{uid}", Field: "query.page"},
+		{Kind: BecameOptional, API: "GET /users/{uid}", Field: "query.size"},
+		{Kind: Added, Breaking: true, API: "GET /users/{uid}", Field: "query.sort"},
+		{Kind: EnumAdded, Breaking: true, API: "GET /users/{uid}", Field: "response(200).sex", New: "unknown"},
+		{Kind: Added, API: "GET /users/{uid}", Field: "response(200).created"},
+		{Kind: Removed, Breaking: true, API: "GET /users/{uid}", Field: "response(200).age"},
+		{Kind: Removed, Breaking: true, API: "DELETE /users/{id}"},
+	})
+	a.True(changes.Breaking())
+
+	// 反向比较
+	changes = Diff(to, from)
+	a.True(changes.Breaking())
+}
+
+func TestDiff_servers(t *testing.T) {
+	a := assert.New(t)
+
+	// 不同服务下请求方法和路径都相同的 API
+	d := newDoc(a, `<apidoc version="1.0.0">
+	<title>title</title>
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<server name="client" url="https://example.com/client" summary="client" />
+	<mimetype>application/json</mimetype>
+	<api method="GET" summary="summary">
+		<path path="/users" />
+		<server>admin</server>
+		<response status="200" type="object" summary="summary">
+			<param name="a" type="string" summary="a" />
+		</response>
+	</api>
+	<api method="GET" summary="summary">
+		<path path="/users" />
+		<server>client</server>
+		<response status="200" type="object" summary="summary">
+			<param name="b" type="string" summary="b" />
+		</response>
+	</api>
+</apidoc>`)
+	a.Empty(Diff(d, d))
+
+	api := d.Apis[1]
+	d2 := *d
+	d2.Apis = []*doc.API{api}
+	a.Equal(Diff(d, &d2), Changes{{Kind: Removed, Breaking: true, API: "GET /users"}})
+	a.Equal(Diff(&d2, d), Changes{{Kind: Added, API: "GET /users"}})
+}
+
+func TestDiffer_param(t *testing.T) {
+	a := assert.New(t)
+
+	d := &differ{}
+	d.param("p", &doc.Param{Type: doc.String}, &doc.Param{Type: doc.String, Array: true}, true)
+	a.Equal(d.changes, Changes{{Kind: TypeChanged, Breaking: true, Field: "p", Old: "string", New: "string[]"}})
+
+	// 客户端发送的参数，删除枚举值是破坏性的。
+	d = &differ{}
+	from := &doc.Param{Type: doc.String, Enums: []*doc.Enum{{Value: "1"}, {Value: "2"}}}
+	to := &doc.Param{Type: doc.String, Enums: []*doc.Enum{{Value: "1"}, {Value: "3"}}}
+	d.param("p", from, to, true)
+	a.Equal(d.changes, Changes{
+		{Kind: EnumAdded, Field: "p", New: "3"},
+		{Kind: EnumRemoved, Breaking: true, Field: "p", Old: "2"},
+	})
+}
+
+func TestAPIKey(t *testing.T) {
+	a := assert.New(t)
+
+	api1 := &doc.API{Method: "GET", Path: &doc.Path{Path: "/users/{id}"}}
+	api2 := &doc.API{Method: "GET", Path: &doc.Path{Path: "/users/{uid}"}}
+	api3 := &doc.API{Method: "POST", Path: &doc.Path{Path: "/users/{id}"}}
+	a.Equal(apiKey(api1), apiKey(api2)).
+		NotEqual(apiKey(api1), apiKey(api3))
+
+	api1.Servers = []string{"admin", "client"}
+	a.Equal(serverKeys(api1), []string{"admin GET /users/{}", "client GET /users/{}"}).
+		Equal(serverKeys(api3), []string{"POST /users/{}"})
+}
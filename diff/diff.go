@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+
+// Package diff 比较两个文档之间的差别
+//
+// 除了列出所有的改动之外，还会区分每一处改动是否会破坏现有的客户端，
+// 并据此给出下一个版本号的建议。
+package diff
+
+import (
+	"github.com/issue9/version"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/internal/locale"
+)
+
+// Kind 表示改动的类型
+type Kind int
+
+// 改动的类型
+const (
+	Added          Kind = iota + 1 // 新增的内容
+	Removed                        // 删除的内容
+	TypeChanged                    // 类型发生了变化，Old 和 New 分别为改动前后的类型
+	EnumAdded                      // 添加了枚举值，New 为该值
+	EnumRemoved                    // 删除了枚举值，Old 为该值
+	BecameRequired                 // 由可选变为必须
+	BecameOptional                 // 由必须变为可选
+	Deprecated                     // 被标记为废弃，New 为废弃的版本号
+)
+
+// Change 表示两个文档之间的一处改动
+type Change struct {
+	Kind     Kind
+	Breaking bool // 是否会破坏现有的客户端
+
+	// 改动所在的 API，以请求方法和路径表示，比如 GET /users/{id}；
+	// 为空表示不属于某个特定的 API，比如 apidoc 中的 response。
+	API string
+
+	// 改动的字段，为空表示 API 本身。
+	//
+	// 由元素名称和参数名称以 . 连接而成，比如 query.page、response(200).user.name 等。
+	Field string
+
+	Old string
+	New string
+}
+
+// Changes 两个文档之间的所有改动
+type Changes []*Change
+
+// Breaking 是否包含了会破坏现有客户端的改动
+func (changes Changes) Breaking() bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Version 根据改动内容给出下一个版本号的建议
+//
+// 有破坏性的改动则升级主版本号，其它改动升级次版本号，没有改动则原样返回 v。
+func (changes Changes) Version(v doc.Version) (doc.Version, error) {
+	if len(changes) == 0 {
+		return v, nil
+	}
+
+	semver, err := version.SemVer(string(v))
+	if err != nil {
+		return "", err
+	}
+
+	if changes.Breaking() {
+		semver.Major++
+		semver.Minor = 0
+	} else {
+		semver.Minor++
+	}
+	semver.Patch = 0
+	semver.PreRelease = ""
+	semver.Build = ""
+
+	return doc.Version(semver.String()), nil
+}
+
+// String 返回本地化的改动信息，由 Location 和 Description 组成。
+func (c *Change) String() string {
+	return locale.Sprintf(locale.DiffMessage, c.Location(), c.Description())
+}
+
+// Location 改动所在的位置，由 API 和 Field 组成。
+func (c *Change) Location() string {
+	switch {
+	case c.API == "":
+		return c.Field
+	case c.Field == "":
+		return c.API
+	default:
+		return c.API + " " + c.Field
+	}
+}
+
+// Description 返回本地化的改动描述
+func (c *Change) Description() string {
+	switch c.Kind {
+	case Added:
+		return locale.Sprintf(locale.DiffAdded)
+	case Removed:
+		return locale.Sprintf(locale.DiffRemoved)
+	case TypeChanged:
+		return locale.Sprintf(locale.DiffTypeChanged, c.Old, c.New)
+	case EnumAdded:
+		return locale.Sprintf(locale.DiffEnumAdded, c.New)
+	case EnumRemoved:
+		return locale.Sprintf(locale.DiffEnumRemoved, c.Old)
+	case BecameRequired:
+		return locale.Sprintf(locale.DiffBecameRequired)
+	case BecameOptional:
+		return locale.Sprintf(locale.DiffBecameOptional)
+	case Deprecated:
+		return locale.Sprintf(locale.DiffDeprecated, c.New)
+	default:
+		return ""
+	}
+}
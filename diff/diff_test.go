@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+)
+
+func TestChanges_Version(t *testing.T) {
+	a := assert.New(t)
+
+	changes := Changes{}
+	v, err := changes.Version("1.2.3")
+	a.NotError(err).Equal(v, "1.2.3").False(changes.Breaking())
+
+	changes = Changes{{Kind: Added}}
+	v, err = changes.Version("1.2.3")
+	a.NotError(err).Equal(v, "1.3.0").False(changes.Breaking())
+
+	changes = Changes{{Kind: Added}, {Kind: Removed, Breaking: true}}
+	v, err = changes.Version("1.2.3-beta+20200101")
+	a.NotError(err).Equal(v, "2.0.0").True(changes.Breaking())
+
+	v, err = changes.Version("invalid")
+	a.Error(err).Equal(v, "")
+}
+
+func TestChange_Location(t *testing.T) {
+	a := assert.New(t)
+
+	c := &Change{API: "GET /users"}
+	a.Equal(c.Location(), "GET /users")
+
+	c.Field = "query.page"
+	a.Equal(c.Location(), "GET /users query.page")
+
+	c.API = ""
+	a.Equal(c.Location(), "query.page")
+}
+
+func TestChange_Description(t *testing.T) {
+	a := assert.New(t)
+
+	for kind := Added; kind <= Deprecated; kind++ {
+		c := &Change{Kind: kind, Old: "old", New: "new"}
+		a.NotEmpty(c.Description()).NotEmpty(c.String())
+	}
+}
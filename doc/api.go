@@ -3,7 +3,6 @@
 package doc
 
 import (
-	"bytes"
 	"encoding/xml"
 
 	"github.com/caixw/apidoc/v6/internal/locale"
@@ -32,6 +31,10 @@ type API struct {
 	Callback    *Callback  `xml:"callback,omitempty"`
 	Deprecated  Version    `xml:"deprecated,attr,omitempty"`
 	Headers     []*Param   `xml:"header,omitempty"`
+	Cookies     []*Param   `xml:"cookie,omitempty"`
+
+	// 身份验证，多个值之间为或的关系，满足其中之一即可。
+	Security []*SecurityRequirement `xml:"security,omitempty"`
 
 	Tags    []string `xml:"tag,omitempty"`
 	Servers []string `xml:"server,omitempty"`
@@ -44,13 +47,17 @@ type API struct {
 
 // NewAPI 从 data 中解析新的 API 对象
 func (doc *Doc) NewAPI(file string, line int, data []byte) error {
+	return doc.newAPI(&Block{File: file, Line: line, Data: data})
+}
+
+func (doc *Doc) newAPI(b *Block) error {
 	api := &API{
-		file: file,
-		line: line,
-		data: data,
+		file: b.File,
+		line: b.Line,
+		data: b.Data,
 		doc:  doc,
 	}
-	if err := xml.Unmarshal(data, api); err != nil {
+	if err := doc.newCollector(b).unmarshal(api); err != nil {
 		return err
 	}
 
@@ -58,21 +65,29 @@ func (doc *Doc) NewAPI(file string, line int, data []byte) error {
 	return nil
 }
 
+// Position 返回 API 在源码中的位置
+//
+// 直接嵌套在 apidoc 中的 API，返回的是 apidoc 的位置。
+func (api *API) Position() (file string, line int) {
+	return api.file, api.line
+}
+
 type shadowAPI API
 
 // UnmarshalXML 实现 xml.Unmarshaler 接口
-func (api *API) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (api *API) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	shadow := (*shadowAPI)(api)
-	if err := d.DecodeElement(shadow, &start); err != nil {
-		// API 可能是嵌套在 apidoc 里的一个子标签。
-		// 如果是子标签，则不应该有 doc 变量，也不需要构建错误信息。
-		if api.doc == nil {
-			return fixedSyntaxError(err, "", field, 0)
-		}
+	// API 可能是嵌套在 apidoc 里的一个子标签，此时 api.file 为空，
+	// 错误信息中的文件名和位置由 collectError 和父元素负责。
+	if err := decodeElement(d, shadow, &start); err != nil {
+		return fixedSyntaxError(err, api.file, field, 0)
+	}
 
-		line := bytes.Count(api.data[:d.InputOffset()], []byte{'\n'})
-		return fixedSyntaxError(err, api.file, field, api.line+line)
+	if err := checkCookies(shadow.Cookies, ""); err != nil {
+		return fixedSyntaxError(err, api.file, field, 0)
 	}
 
 	return nil
@@ -102,5 +117,21 @@ func (api *API) sanitize(field string) error {
 		}
 	}
 
+	for _, req := range api.Security {
+		s := api.doc.getSecurity(req.Name)
+		if s == nil {
+			return message.NewLocaleError(api.file, field+"/security/@name", api.line, locale.ErrInvalidValue)
+		}
+
+		// 仅 oauth2 和 openidconnect 可以指定 scope，
+		// 且 oauth2 的 scope 必须是已经定义的。
+		for _, scope := range req.Scopes {
+			if (s.Type == SecurityOAuth2 && !s.scopeExists(scope)) ||
+				(s.Type != SecurityOAuth2 && s.Type != SecurityOpenIDConnect) {
+				return message.NewLocaleError(api.file, field+"/security/scope", api.line, locale.ErrInvalidValue)
+			}
+		}
+	}
+
 	return nil
 }
@@ -28,6 +28,13 @@ func TestAPI(t *testing.T) {
 	a.Equal(api.Version, "1.1.0").
 		Equal(api.Tags, []string{"g1", "g2"})
 
+	a.Equal(2, len(api.Security))
+	a.Equal(api.Security[1].Name, "oauth").
+		Equal(api.Security[1].Scopes, []string{"read"})
+
+	a.Equal(1, len(api.Cookies))
+	a.Equal(api.Cookies[0].Name, "session")
+
 	a.Equal(len(api.Responses), 2)
 	resp := api.Responses[0]
 	a.Equal(resp.Mimetype, "json").
@@ -38,6 +45,7 @@ func TestAPI(t *testing.T) {
 	a.Equal(sex.Type, String).
 		Equal(sex.Default, "male").
 		Equal(len(sex.Enums), 2)
+	a.Equal(resp.Cookies[0].Name, "lang")
 	example := resp.Examples[0]
 	a.Equal(example.Mimetype, "json").
 		NotEmpty(example.Content)
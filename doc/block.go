@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import "bytes"
+
+// Block 从源码中提取的文档块
+type Block struct {
+	File string
+	Line int // Data 的第一行在源码中的行号
+	Data []byte
+
+	// Data 中每一行在源码中对应行的起始列，从 0 开始。
+	//
+	// 提取注释时会去掉注释符号和缩进，通过此值可以将错误信息定位到源码中的实际位置。
+	// 为空表示所有行都是从源码的第 0 列开始的。
+	Columns []int
+}
+
+var (
+	apidocBegin = []byte("<apidoc")
+	apiBegin    = []byte("<api")
+)
+
+// ParseBlock 根据 b 的内容初始化当前文档或是添加一个新的 API
+//
+// 不是以 apidoc 或是 api 开头的内容会被忽略。
+func (doc *Doc) ParseBlock(b *Block) error {
+	switch {
+	case bytes.HasPrefix(b.Data, apidocBegin):
+		return doc.fromBlock(b)
+	case bytes.HasPrefix(b.Data, apiBegin):
+		return doc.newAPI(b)
+	default:
+		return nil
+	}
+}
+
+// 将 data 中的偏移量 offset 转换成源码中的行号和列号，列号从 1 开始。
+func (b *Block) position(offset int) (line, col int) {
+	if offset > len(b.Data) {
+		offset = len(b.Data)
+	}
+
+	index := bytes.Count(b.Data[:offset], []byte{'\n'})
+	col = offset - (bytes.LastIndexByte(b.Data[:offset], '\n') + 1)
+	if index < len(b.Columns) {
+		col += b.Columns[index]
+	}
+
+	return b.Line + index, col + 1
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/message"
+)
+
+func TestDoc_ParseBlock(t *testing.T) {
+	a := assert.New(t)
+
+	d := New()
+	a.NotError(d.ParseBlock(&Block{Data: []byte("<other />")}))
+
+	err := d.ParseBlock(&Block{
+		File: "api.go",
+		Line: 10,
+		Data: []byte(`<api method="GET" summary="summary">
+<path path="/users">
+<query name="page" type="xx" summary="page" />
+</path>
+</api>`),
+		Columns: []int{3, 3, 3, 3, 3},
+	})
+	serr, ok := err.(*message.SyntaxError)
+	a.True(ok).
+		Equal(serr.File, "api.go").
+		Equal(serr.Field, "/api/path/query/@type").
+		Equal(serr.Line, 12).
+		Equal(serr.Column, 23).
+		Equal(serr.EndLine, 12).
+		Equal(serr.EndColumn, 32)
+	a.Empty(d.Apis)
+
+	// 非属性的错误指向元素的起始标签
+	err = d.ParseBlock(&Block{
+		File: "api.go",
+		Line: 10,
+		Data: []byte(`<api method="GET" summary="summary">
+<path path="/users">
+    <query name="page" type="number" />
+</path>
+</api>`),
+	})
+	serr, ok = err.(*message.SyntaxError)
+	a.True(ok).
+		Equal(serr.Line, 12).
+		Equal(serr.Column, 5).
+		Equal(serr.EndColumn, 40)
+}
+
+func TestBlock_position(t *testing.T) {
+	a := assert.New(t)
+
+	b := &Block{
+		Line:    5,
+		Data:    []byte("l1\nl2\nl3"),
+		Columns: []int{3, 4},
+	}
+
+	line, col := b.position(0)
+	a.Equal(line, 5).Equal(col, 4)
+
+	line, col = b.position(4)
+	a.Equal(line, 6).Equal(col, 6)
+
+	// 超出 Columns 的范围
+	line, col = b.position(7)
+	a.Equal(line, 7).Equal(col, 2)
+
+	line, col = b.position(100)
+	a.Equal(line, 7).Equal(col, 3)
+}
+
+func TestFindAttr(t *testing.T) {
+	a := assert.New(t)
+
+	tag := []byte(`<param name="name" xname="x" type = 'string' summary="type">`)
+
+	start, end := findAttr(tag, "name")
+	a.Equal(start, 7).Equal(end, 18)
+
+	start, end = findAttr(tag, "type")
+	a.Equal(start, 29).Equal(end, 44)
+
+	start, end = findAttr(tag, "not-exists")
+	a.Equal(start, -1).Equal(end, -1)
+}
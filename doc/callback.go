@@ -19,29 +19,44 @@ import (
 //           <param name="age" type="number" />
 //       </request>
 //   </Callback>
+//
+// 也可以通过 ref 引用指定 ID 的 API 作为回调的定义：
+//  <callback ref="webhook" />
 type Callback struct {
 	Method      Method     `xml:"method,attr"`
 	Path        *Path      `xml:"path,omitempty"`
 	Summary     string     `xml:"summary,attr,omitempty"`
 	Description Richtext   `xml:"description,omitempty"`
 	Deprecated  Version    `xml:"deprecated,attr,omitempty"`
-	Reference   string     `xml:"ref,attr,omitempty"`
+	Reference   string     `xml:"ref,attr,omitempty"` // 引用 API.ID 对应的定义
 	Responses   []*Request `xml:"response,omitempty"`
 	Requests    []*Request `xml:"request"` // 至少一个
 	Headers     []*Param   `xml:"header,omitempty"`
+	Cookies     []*Param   `xml:"cookie,omitempty"`
 }
 
 type shadowCallback Callback
 
 // UnmarshalXML xml.Unmarshaler
-func (c *Callback) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (c *Callback) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 
 	shadow := (*shadowCallback)(c)
-	if err := d.DecodeElement(shadow, &start); err != nil {
+	if err := decodeElement(d, shadow, &start); err != nil {
 		return fixedSyntaxError(err, "", field, 0)
 	}
 
+	// 引用其它 API 的定义，则不能再有请求和返回等内容
+	if shadow.Reference != "" {
+		if shadow.Method != "" || shadow.Path != nil || len(shadow.Requests) > 0 ||
+			len(shadow.Responses) > 0 || len(shadow.Headers) > 0 || len(shadow.Cookies) > 0 {
+			return newSyntaxError(field+"/@ref", locale.ErrInvalidValue)
+		}
+		return nil
+	}
+
 	if shadow.Method == "" {
 		return newSyntaxError(field+"/@method", locale.ErrRequired)
 	}
@@ -52,5 +67,5 @@ func (c *Callback) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 
 	// 可以不需要 response
 
-	return nil
+	return checkCookies(shadow.Cookies, field)
 }
@@ -64,6 +64,17 @@ func TestCallback_UnmarshalXML(t *testing.T) {
 	str = `<Callback method="GET" schema="http"></Callback>`
 	a.Error(xml.Unmarshal([]byte(str), obj1))
 
+	// 引用
+	obj1 = &Callback{}
+	str = `<Callback ref="webhook"></Callback>`
+	a.NotError(xml.Unmarshal([]byte(str), obj1)).
+		Equal(obj1.Reference, "webhook")
+
+	// 引用的同时指定了 method
+	obj1 = &Callback{}
+	str = `<Callback ref="webhook" method="GET"></Callback>`
+	a.Error(xml.Unmarshal([]byte(str), obj1))
+
 	// 语法错误
 	obj1 = &Callback{}
 	str = `<Callback name="url" deprecated="x.1.1">text</Callback>`
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import "reflect"
+
+// Clone 返回 doc 的深拷贝
+//
+// 同一份文档输出到多个目标时，各个目标的过滤操作会修改文档的内容，
+// 需要各自在一份副本上进行。
+func (doc *Doc) Clone() *Doc {
+	d := clone(reflect.ValueOf(doc)).Interface().(*Doc)
+	for _, api := range d.Apis {
+		api.doc = d
+	}
+	return d
+}
+
+// 复制 v 的内容，指针和切片指向的内容也会被复制。
+//
+// 未导出的字段直接复制其值，这些字段在解析之后不会再被修改。
+func clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(clone(v.Elem()))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(clone(v.Index(i)))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if f := c.Field(i); f.CanSet() {
+				f.Set(clone(v.Field(i)))
+			}
+		}
+		return c
+	default:
+		return v
+	}
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+)
+
+func TestDoc_Clone(t *testing.T) {
+	a := assert.New(t)
+
+	d := New()
+	a.NotError(d.FromXML("doc.xml", 1, []byte(`<apidoc version="1.1.1">
+	<title>title</title>
+	<tag name="t1" title="t1" />
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<mimetype>application/json</mimetype>
+</apidoc>`)))
+	a.NotError(d.NewAPI("api.go", 10, []byte(`<api method="GET" summary="summary">
+	<path path="/users">
+		<query name="page" type="number" summary="page" />
+	</path>
+	<tag>t1</tag>
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+</api>`)))
+	a.NotError(d.Sanitize())
+
+	c := d.Clone()
+	a.Equal(c, d).
+		True(c.Apis[0].doc == c)
+
+	// 修改副本不影响原文档
+	c.Title = "clone"
+	c.Tags = c.Tags[:0]
+	c.Apis[0].Servers[0] = "client"
+	c.Apis[0].Path.Queries[0].Name = "size"
+	a.Equal(d.Title, "title").
+		Equal(1, len(d.Tags)).
+		Equal(d.Apis[0].Servers[0], "admin").
+		Equal(d.Apis[0].Path.Queries[0].Name, "page").
+		True(d.Apis[0].doc == d)
+
+	file, line := c.Apis[0].Position()
+	a.Equal(file, "api.go").Equal(line, 10)
+}
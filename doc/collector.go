@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"sync"
+
+	"github.com/caixw/apidoc/v6/message"
+)
+
+// 正在解析的解码器及其对应的 collector
+//
+// xml.Unmarshaler 接口无法传递额外的参数，
+// 只能通过 *xml.Decoder 找到其对应的 collector。
+var collectors sync.Map
+
+// 收集解析和检测过程中的语法错误
+//
+// 同时记录了每个元素在源码中的位置，用于确定错误的具体位置。
+//
+// 每个元素只报告其检测到的第一个错误，之后跳过该元素的剩余内容，
+// 继续解析后续的元素，直到达到 max 指定的数量。
+// XML 本身的格式错误则无法继续，会直接中止解析。
+type collector struct {
+	block *Block
+	max   int // 为 0 表示仅收集第一个错误，小于 0 表示不限制数量
+
+	elements []*element // 当前正在解析的元素
+	errs     message.SyntaxErrors
+	stopped  bool // 已经无法继续收集
+}
+
+// 元素在 Block.Data 中的位置
+type element struct {
+	name  string
+	start int // 起始标签的起始位置
+	end   int // 起始标签的结束位置
+}
+
+// SetMaxErrors 设置每个文档块最多收集的错误数量
+//
+// 默认值为 0，表示遇到第一个错误即返回；小于 0 表示不限制数量。
+// 通过 input.Parse 生成的文档，其默认值由 input.Options.MaxErrors 决定，为 10。
+// 非 0 时，FromXML、NewAPI 和 Sanitize 返回的错误为 message.SyntaxErrors。
+func (doc *Doc) SetMaxErrors(max int) {
+	doc.maxErrors = max
+}
+
+func (doc *Doc) newCollector(b *Block) *collector {
+	return &collector{
+		block:    b,
+		max:      doc.maxErrors,
+		elements: make([]*element, 0, 10),
+	}
+}
+
+// 将 Block.Data 解析到 v
+func (c *collector) unmarshal(v interface{}) error {
+	d := xml.NewDecoder(bytes.NewReader(c.block.Data))
+	collectors.Store(d, c)
+	defer collectors.Delete(d)
+
+	if err := d.Decode(v); err != nil && !c.full() {
+		c.add(err)
+	}
+	return c.err()
+}
+
+// 添加错误信息，返回值表示是否可以继续收集。
+func (c *collector) add(err error) bool {
+	serr, ok := err.(*message.SyntaxError)
+	if !ok {
+		serr = message.WithError(c.block.File, "", c.block.Line, err)
+	}
+	c.errs = append(c.errs, serr)
+
+	return !c.full()
+}
+
+func (c *collector) full() bool {
+	return c.max >= 0 && len(c.errs) >= c.max && len(c.errs) > 0
+}
+
+func (c *collector) err() error {
+	switch {
+	case len(c.errs) == 0:
+		return nil
+	case c.max == 0:
+		return c.errs[0]
+	default:
+		return c.errs
+	}
+}
+
+// 在 UnmarshalXML 的开始处以 defer 的形式调用：
+//  defer collectError(d, start)(&err)
+//
+// 返回的函数会为 err 加上当前元素在源码中的位置信息。
+// 在收集错误的模式下，还会将 err 记录下来，并将 err 置为 nil，
+// 使父元素可以继续解析后续的内容。
+func collectError(d *xml.Decoder, start xml.StartElement) func(*error) {
+	v, found := collectors.Load(d)
+	if !found { // 未通过 collector.unmarshal 解析，比如直接调用 xml.Unmarshal。
+		return func(*error) {}
+	}
+	c := v.(*collector)
+
+	// 此时已经读取了整个起始标签，而属性值中不能包含 <，
+	// 所以往前查找到的第一个 < 即为起始标签的开始位置。
+	end := int(d.InputOffset())
+	e := &element{
+		name:  start.Name.Local,
+		start: bytes.LastIndexByte(c.block.Data[:end], '<'),
+		end:   end,
+	}
+	if e.start < 0 {
+		e.start = 0
+	}
+	c.elements = append(c.elements, e)
+
+	return func(err *error) {
+		c.elements = c.elements[:len(c.elements)-1]
+		if *err == nil {
+			return
+		}
+
+		serr := c.syntaxError(*err)
+		if serr.Line == 0 && serr.Column == 0 {
+			c.setPosition(serr, e)
+		}
+
+		if c.max == 0 || c.stopped {
+			*err = serr
+			return
+		}
+
+		names := make([]string, 0, len(c.elements))
+		for _, elem := range c.elements {
+			names = append(names, elem.name)
+		}
+		if len(names) > 0 {
+			serr.Field = strings.Join(names, "/") + serr.Field
+		}
+		if serr.File == "" {
+			serr.File = c.block.File
+		}
+
+		c.stopped = !c.add(serr)
+		*err = nil
+	}
+}
+
+func (c *collector) syntaxError(err error) *message.SyntaxError {
+	switch e := err.(type) {
+	case *message.SyntaxError:
+		return e
+	case *xml.SyntaxError:
+		serr := message.WithError("", "", 0, e)
+		serr.Line = c.block.Line + e.Line - 1
+		return serr
+	default:
+		return message.WithError("", "", 0, e)
+	}
+}
+
+// 根据 serr.Field 确定错误在元素 e 中的具体位置
+//
+// 如果是 e 的属性，则指向该属性，否则指向 e 的起始标签。
+func (c *collector) setPosition(serr *message.SyntaxError, e *element) {
+	start, end := e.start, e.end
+
+	field := strings.TrimPrefix(serr.Field, "/")
+	if prefix := e.name + "/@"; strings.HasPrefix(field, prefix) {
+		if attrStart, attrEnd := findAttr(c.block.Data[start:end], field[len(prefix):]); attrStart >= 0 {
+			start, end = start+attrStart, start+attrEnd
+		}
+	}
+
+	serr.Line, serr.Column = c.block.position(start)
+	serr.EndLine, serr.EndColumn = c.block.position(end)
+}
+
+// 查找名为 name 的属性在 tag 中的起始和结束位置，找不到则返回 -1。
+func findAttr(tag []byte, name string) (start, end int) {
+	for offset := 0; ; {
+		index := bytes.Index(tag[offset:], []byte(name))
+		if index < 0 {
+			return -1, -1
+		}
+		start = offset + index
+		offset = start + len(name)
+
+		if start == 0 || !isSpace(tag[start-1]) {
+			continue
+		}
+
+		i := offset
+		for i < len(tag) && isSpace(tag[i]) {
+			i++
+		}
+		if i >= len(tag) || tag[i] != '=' {
+			continue
+		}
+		i++
+		for i < len(tag) && isSpace(tag[i]) {
+			i++
+		}
+		if i >= len(tag) || (tag[i] != '"' && tag[i] != '\'') {
+			continue
+		}
+
+		if quote := bytes.IndexByte(tag[i+1:], tag[i]); quote >= 0 {
+			return start, i + 1 + quote + 1
+		}
+		return -1, -1
+	}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// 调用 d.DecodeElement 将 start 元素解析到 v
+//
+// v 只能是结构体，否则在出错时，无法确定元素的内容是否已经被读取。
+// 在收集错误的模式下，如果出错的是元素的属性或是子元素的内容，
+// 会跳过当前元素的剩余内容，以便继续解析后续的元素。
+func decodeElement(d *xml.Decoder, v interface{}, start *xml.StartElement) error {
+	err := d.DecodeElement(v, start)
+	if err == nil {
+		return nil
+	}
+
+	val, found := collectors.Load(d)
+	if !found {
+		return err
+	}
+	c := val.(*collector)
+
+	if c.max == 0 || c.stopped {
+		return err
+	}
+
+	if _, ok := err.(*xml.SyntaxError); ok {
+		c.stopped = true
+		return err
+	}
+
+	if skipErr := d.Skip(); skipErr != nil {
+		c.stopped = true
+		return skipErr
+	}
+	return err
+}
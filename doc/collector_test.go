@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/message"
+)
+
+const collectorDoc = `<apidoc version="1.1.1">
+	<title>title</title>
+	<tag name="t1" />
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<type name="user" type="object">
+		<param name="id" type="not-exists" summary="id" />
+		<param name="name" type="string" />
+	</type>
+	<mimetype>application/json</mimetype>
+</apidoc>`
+
+func TestDoc_FromXML_collect(t *testing.T) {
+	a := assert.New(t)
+
+	// 默认只返回第一个错误
+	d := New()
+	err := d.FromXML("doc.xml", 10, []byte(collectorDoc))
+	a.Error(err)
+	_, ok := err.(*message.SyntaxError)
+	a.True(ok)
+
+	d = New()
+	d.SetMaxErrors(-1)
+	err = d.FromXML("doc.xml", 10, []byte(collectorDoc))
+	errs, ok := err.(message.SyntaxErrors)
+	a.True(ok).Equal(4, len(errs))
+	a.Equal(errs[0].Field, "apidoc/tag/@title").
+		Equal(errs[0].File, "doc.xml").
+		Equal(errs[0].Line, 12)
+	a.Equal(errs[1].Field, "apidoc/type/param/@type").Equal(errs[1].Line, 15)
+	a.Equal(errs[2].Field, "apidoc/type/param/summary").Equal(errs[2].Line, 16)
+	a.Equal(errs[3].Field, "apidoc/type/summary").Equal(errs[3].Line, 14)
+
+	// 限定数量
+	d = New()
+	d.SetMaxErrors(2)
+	err = d.FromXML("doc.xml", 10, []byte(collectorDoc))
+	errs, ok = err.(message.SyntaxErrors)
+	a.True(ok).Equal(2, len(errs))
+
+	// XML 格式错误，无法继续
+	d = New()
+	d.SetMaxErrors(-1)
+	err = d.FromXML("doc.xml", 10, []byte(`<apidoc version="1.1.1"><tag name="t1" /><title>title</apidoc>`))
+	errs, ok = err.(message.SyntaxErrors)
+	a.True(ok).Equal(2, len(errs))
+
+	// 没有错误
+	d = New()
+	d.SetMaxErrors(-1)
+	a.NotError(d.FromXML("doc.xml", 10, []byte(unionDoc)))
+}
+
+func TestDoc_NewAPI_collect(t *testing.T) {
+	a := assert.New(t)
+
+	d := New()
+	d.SetMaxErrors(10)
+	err := d.NewAPI("api.go", 5, []byte(`<api method="GET" summary="summary">
+	<path path="/users">
+		<query name="page" type="number" />
+	</path>
+	<response status="200" type="invalid" summary="summary" />
+</api>`))
+	errs, ok := err.(message.SyntaxErrors)
+	a.True(ok).Equal(2, len(errs)).Empty(d.Apis)
+	a.Equal(errs[0].Field, "api/path/query/summary").
+		Equal(errs[0].File, "api.go").
+		Equal(errs[0].Line, 7)
+	a.Equal(errs[1].Field, "api/response/@type").Equal(errs[1].Line, 9)
+}
+
+func TestDoc_Sanitize_collect(t *testing.T) {
+	a := assert.New(t)
+
+	d := New()
+	d.SetMaxErrors(-1)
+	a.NotError(d.FromXML("doc.xml", 1, []byte(`<apidoc version="1.1.1">
+	<title>title</title>
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<mimetype>application/json</mimetype>
+</apidoc>`)))
+	a.NotError(d.NewAPI("api.go", 1, []byte(`<api method="GET" summary="summary">
+	<path path="/users" />
+	<tag>not-exists</tag>
+	<server>admin</server>
+	<response status="200" ref="not-exists" />
+</api>`)))
+	a.NotError(d.NewAPI("api.go", 10, []byte(`<api method="POST" summary="summary">
+	<path path="/users" />
+	<server>not-exists</server>
+	<response status="200" type="string" summary="summary" />
+</api>`)))
+
+	err := d.Sanitize()
+	errs, ok := err.(message.SyntaxErrors)
+	a.True(ok).Equal(3, len(errs))
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"math"
+	"regexp"
+
+	"github.com/caixw/apidoc/v6/internal/locale"
+)
+
+// Constraint.Format 的可选值
+const (
+	FormatDate     = "date"
+	FormatDateTime = "date-time"
+	FormatEmail    = "email"
+	FormatUUID     = "uuid"
+	FormatURI      = "uri"
+	FormatBinary   = "binary"
+)
+
+// Constraint 对参数值的约束条件
+type Constraint struct {
+	Format    string   `xml:"format,attr,omitempty"`     // 字符串的格式
+	Min       *float64 `xml:"min,attr,omitempty"`        // 数值的最小值
+	Max       *float64 `xml:"max,attr,omitempty"`        // 数值的最大值
+	MinLength int      `xml:"min-length,attr,omitempty"` // 字符串的最小长度
+	MaxLength int      `xml:"max-length,attr,omitempty"` // 字符串的最大长度
+	Pattern   string   `xml:"pattern,attr,omitempty"`    // 字符串需要匹配的正则表达式
+	MinItems  int      `xml:"min-items,attr,omitempty"`  // 数组的最小长度
+	MaxItems  int      `xml:"max-items,attr,omitempty"`  // 数组的最大长度
+
+	regexp *regexp.Regexp // Pattern 编译后的内容
+}
+
+// IsZero 是否未指定任何约束条件
+func (c *Constraint) IsZero() bool {
+	return c.Format == "" &&
+		c.Min == nil &&
+		c.Max == nil &&
+		c.MinLength == 0 &&
+		c.MaxLength == 0 &&
+		c.Pattern == "" &&
+		c.MinItems == 0 &&
+		c.MaxItems == 0
+}
+
+// Regexp 返回 Pattern 编译后的正则表达式，Pattern 为空时返回 nil。
+//
+// 从 XML 加载的文档，会缓存编译后的结果。
+func (c *Constraint) Regexp() (*regexp.Regexp, error) {
+	if c.Pattern == "" {
+		return nil, nil
+	}
+
+	if c.regexp != nil && c.regexp.String() == c.Pattern {
+		return c.regexp, nil
+	}
+	return regexp.Compile(c.Pattern)
+}
+
+func checkConstraint(t Type, isArray bool, c *Constraint, field string) error {
+	if c.Format != "" {
+		if t != String {
+			return newSyntaxError(field+"/@format", locale.ErrInvalidValue)
+		}
+
+		switch c.Format {
+		case FormatDate, FormatDateTime, FormatEmail, FormatUUID, FormatURI, FormatBinary:
+		default:
+			return newSyntaxError(field+"/@format", locale.ErrInvalidValue)
+		}
+	}
+
+	if c.Min != nil || c.Max != nil {
+		if !t.IsNumber() {
+			return newSyntaxError(field+"/@min", locale.ErrInvalidValue)
+		}
+
+		if c.Min != nil && c.Max != nil {
+			if *c.Min > *c.Max {
+				return newSyntaxError(field+"/@max", locale.ErrInvalidValue)
+			}
+
+			// 整数类型的取值范围内至少要包含一个整数
+			if t == Integer && math.Ceil(*c.Min) > math.Floor(*c.Max) {
+				return newSyntaxError(field+"/@max", locale.ErrInvalidValue)
+			}
+		}
+	}
+
+	if c.MinLength != 0 || c.MaxLength != 0 || c.Pattern != "" {
+		if t != String {
+			return newSyntaxError(field+"/@min-length", locale.ErrInvalidValue)
+		}
+
+		if c.MinLength < 0 || c.MaxLength < 0 || (c.MaxLength > 0 && c.MinLength > c.MaxLength) {
+			return newSyntaxError(field+"/@max-length", locale.ErrInvalidValue)
+		}
+
+		if c.Pattern != "" {
+			expr, err := regexp.Compile(c.Pattern)
+			if err != nil {
+				return newSyntaxError(field+"/@pattern", locale.ErrInvalidFormat)
+			}
+			c.regexp = expr
+		}
+	}
+
+	if c.MinItems != 0 || c.MaxItems != 0 {
+		if !isArray {
+			return newSyntaxError(field+"/@min-items", locale.ErrInvalidValue)
+		}
+
+		if c.MinItems < 0 || c.MaxItems < 0 || (c.MaxItems > 0 && c.MinItems > c.MaxItems) {
+			return newSyntaxError(field+"/@max-items", locale.ErrInvalidValue)
+		}
+	}
+
+	return nil
+}
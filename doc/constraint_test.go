@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+)
+
+func TestConstraint_IsZero(t *testing.T) {
+	a := assert.New(t)
+
+	c := &Constraint{}
+	a.True(c.IsZero())
+
+	min := 0.0
+	c.Min = &min
+	a.False(c.IsZero())
+
+	c = &Constraint{Pattern: "^a"}
+	a.False(c.IsZero())
+}
+
+func TestConstraint_Regexp(t *testing.T) {
+	a := assert.New(t)
+
+	c := &Constraint{}
+	expr, err := c.Regexp()
+	a.NotError(err).Nil(expr)
+
+	c.Pattern = "[a-z"
+	expr, err = c.Regexp()
+	a.Error(err).Nil(expr)
+
+	// checkConstraint 会缓存编译后的内容
+	c.Pattern = "^[a-z]+$"
+	a.NotError(checkConstraint(String, false, c, ""))
+	expr, err = c.Regexp()
+	a.NotError(err).Equal(expr, c.regexp)
+	a.True(expr.MatchString("abc"))
+
+	// 修改 Pattern 之后，缓存失效
+	c.Pattern = "^[0-9]+$"
+	expr, err = c.Regexp()
+	a.NotError(err).NotEqual(expr, c.regexp)
+	a.True(expr.MatchString("123"))
+}
+
+func TestCheckConstraint(t *testing.T) {
+	a := assert.New(t)
+
+	min := 5.0
+	max := 10.0
+
+	a.NotError(checkConstraint(String, false, &Constraint{}, ""))
+	a.NotError(checkConstraint(Object, false, &Constraint{}, ""))
+
+	// format
+	a.NotError(checkConstraint(String, false, &Constraint{Format: FormatUUID}, ""))
+	a.Error(checkConstraint(String, false, &Constraint{Format: "not-exists"}, ""))
+	a.Error(checkConstraint(Number, false, &Constraint{Format: FormatUUID}, ""))
+
+	// min/max
+	a.NotError(checkConstraint(Integer, false, &Constraint{Min: &min, Max: &max}, ""))
+	a.NotError(checkConstraint(Float, false, &Constraint{Max: &max}, ""))
+	a.Error(checkConstraint(Number, false, &Constraint{Min: &max, Max: &min}, ""))
+	a.Error(checkConstraint(String, false, &Constraint{Min: &min}, ""))
+	fmin, fmax := 0.1, 0.9 // 不包含整数的范围
+	a.Error(checkConstraint(Integer, false, &Constraint{Min: &fmin, Max: &fmax}, ""))
+	a.NotError(checkConstraint(Number, false, &Constraint{Min: &fmin, Max: &fmax}, ""))
+
+	// min-length/max-length/pattern
+	a.NotError(checkConstraint(String, false, &Constraint{MinLength: 5, MaxLength: 10}, ""))
+	a.NotError(checkConstraint(String, false, &Constraint{MinLength: 5}, ""))
+	a.Error(checkConstraint(String, false, &Constraint{MinLength: 10, MaxLength: 5}, ""))
+	a.Error(checkConstraint(String, false, &Constraint{MinLength: -1}, ""))
+	a.Error(checkConstraint(Number, false, &Constraint{MaxLength: 5}, ""))
+	a.NotError(checkConstraint(String, false, &Constraint{Pattern: "^[a-z]+$"}, ""))
+	a.Error(checkConstraint(String, false, &Constraint{Pattern: "[a-z"}, ""))
+	a.Error(checkConstraint(Bool, false, &Constraint{Pattern: "^[a-z]+$"}, ""))
+
+	// min-items/max-items
+	a.NotError(checkConstraint(String, true, &Constraint{MinItems: 1, MaxItems: 5}, ""))
+	a.Error(checkConstraint(String, false, &Constraint{MinItems: 1}, ""))
+	a.Error(checkConstraint(String, true, &Constraint{MinItems: 5, MaxItems: 1}, ""))
+}
@@ -4,7 +4,6 @@
 package doc
 
 import (
-	"bytes"
 	"encoding/xml"
 	"sort"
 	"time"
@@ -45,7 +44,18 @@ type Doc struct {
 	License     *Link     `xml:"license,omitempty"` // 版本信息
 	Tags        []*Tag    `xml:"tag,omitempty"`     // 所有的标签
 	Servers     []*Server `xml:"server,omitempty"`
-	Apis        []*API    `xml:"api,omitempty"`
+
+	// 身份验证方案
+	//
+	// 可以被 api 中的 security 引用。
+	Security []*Security `xml:"security,omitempty"`
+
+	// 可复用的类型定义
+	//
+	// 可以被 param 和 request 的 ref 属性引用。
+	Types []*Param `xml:"type,omitempty"`
+
+	Apis []*API `xml:"api,omitempty"`
 
 	// 表示所有 API 都有可能返回的内容
 	Responses []*Request `xml:"response,omitempty"`
@@ -53,9 +63,10 @@ type Doc struct {
 	// 表示所有接口都支持的文档类型
 	Mimetypes []string `xml:"mimetype"`
 
-	file string
-	line int
-	data []byte
+	file      string
+	line      int
+	data      []byte
+	maxErrors int
 }
 
 // Valid 验证文档内容的正确性
@@ -71,16 +82,22 @@ func New() *Doc {
 	}
 }
 
+// Position 返回 apidoc 在源码中的位置
+func (doc *Doc) Position() (file string, line int) {
+	return doc.file, doc.line
+}
+
 type shadowDoc Doc
 
 // UnmarshalXML 实现 xml.Unmarshaler 接口
 //
 // 返回的错误信息都为 message.SyntaxError 实例
-func (doc *Doc) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (doc *Doc) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	shadow := (*shadowDoc)(doc)
-	if err := d.DecodeElement(shadow, &start); err != nil {
-		line := bytes.Count(doc.data[:d.InputOffset()], []byte{'\n'})
-		return fixedSyntaxError(err, doc.file, "apidoc", doc.line+line)
+	if err := decodeElement(d, shadow, &start); err != nil {
+		return fixedSyntaxError(err, doc.file, "apidoc", 0)
 	}
 
 	// Tag.Name 查重
@@ -93,6 +110,16 @@ func (doc *Doc) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		return message.NewLocaleError(doc.file, "apidoc/server/@name", doc.line, locale.ErrDuplicateValue)
 	}
 
+	// Security.Name 查重
+	if key := findDupSecurity(shadow.Security); key != "" {
+		return message.NewLocaleError(doc.file, "apidoc/security/@name", doc.line, locale.ErrDuplicateValue)
+	}
+
+	// Types.Name 查重
+	if key := getDuplicateItems(shadow.Types); key != "" {
+		return message.NewLocaleError(doc.file, "apidoc/type/@name", doc.line, locale.ErrDuplicateValue)
+	}
+
 	if len(shadow.Mimetypes) == 0 {
 		return message.NewLocaleError(doc.file, "apidoc/mimetype", doc.line, locale.ErrRequired)
 	}
@@ -107,9 +134,14 @@ func (doc *Doc) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		}
 	}
 
-	apis := doc.Apis
-	if len(shadow.Apis) > 0 {
-		apis = append(apis, shadow.Apis...)
+	// 直接嵌套在 apidoc 中的 api，需要关联当前文档，
+	// 否则在 Sanitize 中无法检测其标签和服务等内容。
+	for _, api := range shadow.Apis {
+		if api.doc == nil {
+			api.doc = doc
+			api.file = doc.file
+			api.line = doc.line
+		}
 	}
 
 	return nil
@@ -120,14 +152,29 @@ func (doc *Doc) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 // file 和 line 仅用于在出错时定位错误的位置，并无其它用处；
 // data 表示 XML 内容。
 func (doc *Doc) FromXML(file string, line int, data []byte) error {
-	doc.file = file
-	doc.line = line
-	doc.data = data
-	return xml.Unmarshal(data, doc)
+	return doc.fromBlock(&Block{File: file, Line: line, Data: data})
+}
+
+func (doc *Doc) fromBlock(b *Block) error {
+	doc.file = b.File
+	doc.line = b.Line
+	doc.data = b.Data
+	return doc.newCollector(b).unmarshal(doc)
 }
 
 // Sanitize 检测内容是否合法
 func (doc *Doc) Sanitize() error {
+	c := doc.newCollector(&Block{File: doc.file, Line: doc.line})
+
+	if !doc.checkDuplicateIDs(c) {
+		return c.err()
+	}
+
+	// 需要在排序之前处理，引用的路径在解析之后才有值。
+	if !doc.resolveReferences(c) {
+		return c.err()
+	}
+
 	// doc.Apis 是多线程导入的，无法保证其顺序，
 	// 此处可以保证输出内容是按一定顺序排列的。
 	sort.SliceStable(doc.Apis, func(i, j int) bool {
@@ -140,13 +187,17 @@ func (doc *Doc) Sanitize() error {
 		return ii.Path.Path < jj.Path.Path
 	})
 
+	if !doc.checkDuplicateAPIs(c) {
+		return c.err()
+	}
+
 	for _, api := range doc.Apis { // 查看 API 中的标签是否都存在
-		if err := api.sanitize("api"); err != nil {
-			return err
+		if err := api.sanitize("api"); err != nil && !c.add(err) {
+			break
 		}
 	}
 
-	return nil
+	return c.err()
 }
 
 func (doc *Doc) tagExists(tag string) bool {
@@ -167,10 +218,17 @@ func (doc *Doc) serverExists(srv string) bool {
 	return false
 }
 
+// 为 err 加上文件名和字段名等信息
+//
+// 如果 err 中已经包含了文件名和行号，则不会再修改。
 func fixedSyntaxError(err error, file, field string, line int) error {
 	if serr, ok := err.(*message.SyntaxError); ok {
-		serr.File = file
-		serr.Line = line
+		if serr.File == "" {
+			serr.File = file
+		}
+		if serr.Line == 0 {
+			serr.Line = line
+		}
 
 		if serr.Field == "" {
 			serr.Field = field
@@ -66,6 +66,14 @@ func TestDoc(t *testing.T) {
 
 	a.Equal(2, len(doc.Mimetypes)).
 		Equal("application/xml", doc.Mimetypes[0])
+
+	a.Equal(2, len(doc.Security))
+	oauth := doc.getSecurity("oauth")
+	a.NotNil(oauth).
+		Equal(oauth.Type, SecurityOAuth2).
+		True(oauth.scopeExists("read")).
+		False(oauth.scopeExists("not-exists"))
+	a.Nil(doc.getSecurity("not-exists"))
 }
 
 func TestDoc_all(t *testing.T) {
@@ -133,6 +141,30 @@ func TestDoc_UnmarshalXML(t *testing.T) {
 	a.Equal(serr.Line, 12).
 		Equal(serr.File, "file")
 
+	// 重复的 type
+	data = `<apidoc version="1.1.1">
+		<type name="t1" type="string" summary="t1" />
+		<type name="t1" type="number" summary="t1" />
+	</apidoc>`
+	doc = New()
+	err = doc.FromXML("file", 13, []byte(data))
+	serr, ok = err.(*message.SyntaxError)
+	a.True(ok).NotNil(serr)
+	a.Equal(serr.Line, 13).
+		Equal(serr.Field, "apidoc/type/@name")
+
+	// 重复的 security
+	data = `<apidoc version="1.1.1">
+		<security name="s1" type="http" scheme="basic" />
+		<security name="s1" type="http" scheme="bearer" />
+	</apidoc>`
+	doc = New()
+	err = doc.FromXML("file", 14, []byte(data))
+	serr, ok = err.(*message.SyntaxError)
+	a.True(ok).NotNil(serr)
+	a.Equal(serr.Line, 14).
+		Equal(serr.Field, "apidoc/security/@name")
+
 	data = `<apidoc version="1.1.1">
 			<tag name="t1" deprecated="x.0.1" />
 		</apidoc>`
@@ -188,6 +220,35 @@ func TestDoc_Sanitize(t *testing.T) {
 		},
 	}
 	a.Error(doc.Sanitize())
+
+	// api.security
+	doc.Security = []*Security{
+		{Name: "token", Type: SecurityAPIKey, IN: SecurityInHeader, Key: "X-Token"},
+		{Name: "oauth", Type: SecurityOAuth2, Flows: []*OAuthFlow{
+			{Type: FlowPassword, Scopes: []*Scope{{Name: "read"}}},
+		}},
+	}
+	api := &API{
+		Servers:  []string{"tag1"},
+		doc:      doc,
+		Path:     &Path{},
+		Method:   http.MethodGet,
+		Security: []*SecurityRequirement{{Name: "token"}, {Name: "oauth", Scopes: []string{"read"}}},
+	}
+	doc.Apis = []*API{api}
+	a.NotError(doc.Sanitize())
+
+	// 不存在的 security
+	api.Security = []*SecurityRequirement{{Name: "not-exists"}}
+	a.Error(doc.Sanitize())
+
+	// 不存在的 scope
+	api.Security = []*SecurityRequirement{{Name: "oauth", Scopes: []string{"not-exists"}}}
+	a.Error(doc.Sanitize())
+
+	// apikey 不能指定 scope
+	api.Security = []*SecurityRequirement{{Name: "token", Scopes: []string{"read"}}}
+	a.Error(doc.Sanitize())
 }
 
 // 测试错误提示的行号是否正确
@@ -198,7 +259,11 @@ func TestDoc_lineNumber(t *testing.T) {
 
 	data := []byte(`<apidoc version="x.0.1"></apidoc>`)
 	err := doc.FromXML("file", 11, data)
-	a.Equal(err.(*message.SyntaxError).Line, 11)
+	serr := err.(*message.SyntaxError)
+	a.Equal(serr.Line, 11).
+		Equal(serr.Column, 9).
+		Equal(serr.EndLine, 11).
+		Equal(serr.EndColumn, 24)
 
 	data = []byte(`<apidoc
 	
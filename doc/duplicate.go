@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/message"
+)
+
+// 检测重复的 API.ID，错误信息会被添加到 c，返回值表示是否可以继续。
+//
+// 需要在解析引用之前调用，否则引用的可能是任意一个同名的 API。
+func (doc *Doc) checkDuplicateIDs(c *collector) bool {
+	ids := make(map[string][]*API, len(doc.Apis))
+	for _, api := range doc.Apis {
+		if api.ID != "" {
+			ids[api.ID] = append(ids[api.ID], api)
+		}
+	}
+
+	keys := make([]string, 0, len(ids))
+	for id, apis := range ids {
+		if len(apis) > 1 {
+			keys = append(keys, id)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, id := range keys {
+		apis := sortAPIsByPosition(ids[id])
+		first := apis[0]
+		for _, api := range apis[1:] {
+			err := message.NewLocaleError(api.file, "api/@id", api.line, locale.ErrDuplicateAPIID, first.file, first.line)
+			if !c.add(err) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// 检测重复的 API 以及存在冲突的路由，错误信息会被添加到 c，返回值表示是否可以继续。
+//
+// 对于拥有相同 server 的 API：请求方法和路径都相同的被认为是重复的 API；
+// 路径仅参数名称不同的，路由无法区分，被认为是冲突的路由，比如 /users/{id} 和 /users/{uid}。
+//
+// 需要在解析引用之后调用，引用的路径在解析之后才有值。
+func (doc *Doc) checkDuplicateAPIs(c *collector) bool {
+	routes := make(map[string][]*API, len(doc.Apis))
+	for _, api := range doc.Apis {
+		key := RouteKey(api.Path.Path)
+		routes[key] = append(routes[key], api)
+	}
+
+	keys := make([]string, 0, len(routes))
+	for key, apis := range routes {
+		if len(apis) > 1 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		apis := sortAPIsByPosition(routes[key])
+		for i, first := range apis {
+			for _, api := range apis[i+1:] {
+				if !hasSameServer(first, api) {
+					continue
+				}
+
+				var err error
+				switch {
+				case api.Path.Path != first.Path.Path:
+					err = message.NewLocaleError(api.file, "api/path/@path", api.line, locale.ErrRouteConflict, first.file, first.line)
+				case api.Method == first.Method:
+					err = message.NewLocaleError(api.file, "api/@method", api.line, locale.ErrDuplicateAPI, first.file, first.line)
+				default:
+					continue
+				}
+
+				if !c.add(err) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// RouteKey 去掉路径中的参数名称
+//
+// 仅参数名称不同的路径会返回相同的值，比如 /users/{id} 和 /users/{uid} 都返回 /users/{}。
+func RouteKey(path string) string {
+	var key strings.Builder
+	key.Grow(len(path))
+
+	inParam := false
+	for _, b := range path {
+		switch {
+		case b == '{':
+			inParam = true
+			key.WriteRune(b)
+		case b == '}':
+			inParam = false
+			key.WriteRune(b)
+		case !inParam:
+			key.WriteRune(b)
+		}
+	}
+
+	return key.String()
+}
+
+func hasSameServer(api1, api2 *API) bool {
+	for _, srv1 := range api1.Servers {
+		for _, srv2 := range api2.Servers {
+			if srv1 == srv2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 按 API 在源码中的位置排序，保证每次输出的错误信息都是相同的。
+//
+// doc.Apis 是多线程导入的，其顺序并不固定。
+func sortAPIsByPosition(apis []*API) []*API {
+	sort.SliceStable(apis, func(i, j int) bool {
+		if apis[i].file == apis[j].file {
+			return apis[i].line < apis[j].line
+		}
+		return apis[i].file < apis[j].file
+	})
+	return apis
+}
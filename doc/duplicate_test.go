@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/message"
+)
+
+const duplicateDoc = `<apidoc version="1.1.1">
+	<title>title</title>
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<server name="client" url="https://example.com/client" summary="client" />
+	<mimetype>application/json</mimetype>
+</apidoc>`
+
+func newDuplicateDoc(a *assert.Assertion, apis map[int]string) *Doc {
+	d := New()
+	d.SetMaxErrors(-1)
+	a.NotError(d.FromXML("doc.xml", 1, []byte(duplicateDoc)))
+	for line, api := range apis {
+		a.NotError(d.NewAPI("api.go", line, []byte(api)))
+	}
+	return d
+}
+
+func TestDoc_checkDuplicateIDs(t *testing.T) {
+	a := assert.New(t)
+
+	d := newDuplicateDoc(a, map[int]string{
+		30: `<api method="GET" id="users" summary="s"><path path="/users" /><server>admin</server><response status="200" type="string" summary="s" /></api>`,
+		10: `<api method="POST" id="users" summary="s"><path path="/users" /><server>admin</server><response status="200" type="string" summary="s" /></api>`,
+		20: `<api method="PUT" id="user" summary="s"><path path="/users" /><server>admin</server><response status="200" type="string" summary="s" /></api>`,
+	})
+	errs, ok := d.Sanitize().(message.SyntaxErrors)
+	a.True(ok).Equal(1, len(errs))
+	a.Equal(errs[0].Field, "api/@id").
+		Equal(errs[0].Line, 30).
+		Equal(errs[0].Code, "E-DUPLICATE-API-ID").
+		Contains(errs[0].Message, "api.go:10")
+}
+
+func TestDoc_checkDuplicateAPIs(t *testing.T) {
+	a := assert.New(t)
+
+	// 相同的 method、path 和 server
+	d := newDuplicateDoc(a, map[int]string{
+		30: `<api method="GET" summary="s"><path path="/users" /><server>admin</server><response status="200" type="string" summary="s" /></api>`,
+		10: `<api method="GET" summary="s"><path path="/users" /><server>admin</server><server>client</server><response status="200" type="string" summary="s" /></api>`,
+	})
+	errs, ok := d.Sanitize().(message.SyntaxErrors)
+	a.True(ok).Equal(1, len(errs))
+	a.Equal(errs[0].Field, "api/@method").
+		Equal(errs[0].Line, 30).
+		Equal(errs[0].Code, "E-DUPLICATE-API").
+		Contains(errs[0].Message, "api.go:10")
+
+	// 不同的 server
+	d = newDuplicateDoc(a, map[int]string{
+		30: `<api method="GET" summary="s"><path path="/users" /><server>admin</server><response status="200" type="string" summary="s" /></api>`,
+		10: `<api method="GET" summary="s"><path path="/users" /><server>client</server><response status="200" type="string" summary="s" /></api>`,
+	})
+	a.NotError(d.Sanitize())
+
+	// 仅参数名称不同
+	d = newDuplicateDoc(a, map[int]string{
+		30: `<api method="DELETE" summary="s"><path path="/users/{uid}"><param name="uid" type="number" summary="s" /></path><server>admin</server><response status="200" type="string" summary="s" /></api>`,
+		10: `<api method="GET" summary="s"><path path="/users/{id}"><param name="id" type="number" summary="s" /></path><server>admin</server><response status="200" type="string" summary="s" /></api>`,
+		20: `<api method="PUT" summary="s"><path path="/users/{id}"><param name="id" type="number" summary="s" /></path><server>admin</server><response status="200" type="string" summary="s" /></api>`,
+	})
+	errs, ok = d.Sanitize().(message.SyntaxErrors)
+	a.True(ok).Equal(2, len(errs))
+	a.Equal(errs[0].Field, "api/path/@path").
+		Equal(errs[0].Line, 30).
+		Equal(errs[0].Code, "E-ROUTE-CONFLICT").
+		Contains(errs[0].Message, "api.go:10")
+	a.Equal(errs[1].Line, 30).Contains(errs[1].Message, "api.go:20")
+}
+
+func TestRouteKey(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(RouteKey("/users"), "/users")
+	a.Equal(RouteKey("/users/{id}"), "/users/{}")
+	a.Equal(RouteKey("/users/{id}/groups/{gid}.json"), "/users/{}/groups/{}.json")
+	a.Equal(RouteKey("/users/{id}"), RouteKey("/users/{uid}"))
+}
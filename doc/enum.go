@@ -21,10 +21,12 @@ type Enum struct {
 type shadowEnum Enum
 
 // UnmarshalXML xml.Unmarshaler
-func (e *Enum) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (e *Enum) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	shadow := (*shadowEnum)(e)
-	if err := d.DecodeElement(shadow, &start); err != nil {
+	if err := decodeElement(d, shadow, &start); err != nil {
 		return fixedSyntaxError(err, "", field, 0)
 	}
 
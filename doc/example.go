@@ -19,10 +19,12 @@ type Example struct {
 type shadowExample Example
 
 // UnmarshalXML xml.Unmarshaler
-func (e *Example) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (e *Example) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	shadow := (*shadowExample)(e)
-	if err := d.DecodeElement(shadow, &start); err != nil {
+	if err := decodeElement(d, shadow, &start); err != nil {
 		return fixedSyntaxError(err, "", field, 0)
 	}
 
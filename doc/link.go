@@ -34,10 +34,12 @@ type (
 )
 
 // UnmarshalXML xml.Unmarshaler
-func (l *Link) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (l *Link) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	shadow := (*shadowLink)(l)
-	if err := d.DecodeElement(shadow, &start); err != nil {
+	if err := decodeElement(d, shadow, &start); err != nil {
 		return fixedSyntaxError(err, "", field, 0)
 	}
 
@@ -53,10 +55,12 @@ func (l *Link) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 }
 
 // UnmarshalXML xml.Unmarshaler
-func (c *Contact) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (c *Contact) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	shadow := (*shadowContact)(c)
-	if err := d.DecodeElement(shadow, &start); err != nil {
+	if err := decodeElement(d, shadow, &start); err != nil {
 		return fixedSyntaxError(err, "", field, 0)
 	}
 
@@ -47,7 +47,9 @@ func (m *Method) UnmarshalXMLAttr(attr xml.Attr) error {
 }
 
 // UnmarshalXML xml.Unmarshaler
-func (m *Method) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (m *Method) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	var str string
 	if err := d.DecodeElement(&str, &start); err != nil {
@@ -23,14 +23,20 @@ import (
 //  </param>
 type Param struct {
 	XML
+	Constraint
 	Name        string   `xml:"name,attr"`
 	Type        Type     `xml:"type,attr"`
 	Deprecated  Version  `xml:"deprecated,attr,omitempty"`
 	Default     string   `xml:"default,attr,omitempty"`
 	Optional    bool     `xml:"optional,attr,omitempty"`
+	Nullable    bool     `xml:"nullable,attr,omitempty"`  // 是否可以为 null
+	ReadOnly    bool     `xml:"readonly,attr,omitempty"`  // 仅出现在返回对象中，比如由服务端生成的 id
+	WriteOnly   bool     `xml:"writeonly,attr,omitempty"` // 仅出现在请求对象中，比如密码
 	Array       bool     `xml:"array,attr,omitempty"`
 	Items       []*Param `xml:"param,omitempty"`
-	Reference   string   `xml:"ref,attr,omitempty"`
+	Reference   string   `xml:"ref,attr,omitempty"` // 引用 doc.Types 中的类型
+	OneOf       *Union   `xml:"one-of,omitempty"`   // 值只能匹配其中一个子类型
+	AnyOf       *Union   `xml:"any-of,omitempty"`   // 值至少匹配其中一个子类型
 	Summary     string   `xml:"summary,attr,omitempty"`
 	Enums       []*Enum  `xml:"enum,omitempty"`
 	Description Richtext `xml:"description,omitempty"`
@@ -46,6 +52,7 @@ func (r *Request) Param() *Param {
 
 	return &Param{
 		XML:         r.XML,
+		Constraint:  r.Constraint,
 		Name:        r.Name,
 		Type:        r.Type,
 		Deprecated:  r.Deprecated,
@@ -54,6 +61,8 @@ func (r *Request) Param() *Param {
 		Array:       r.Array,
 		Items:       r.Items,
 		Reference:   r.Reference,
+		OneOf:       r.OneOf,
+		AnyOf:       r.AnyOf,
 		Summary:     r.Summary,
 		Enums:       r.Enums,
 		Description: r.Description,
@@ -68,10 +77,12 @@ func (p *Param) IsEnum() bool {
 type shadowParam Param
 
 // UnmarshalXML xml.Unmarshaler
-func (p *Param) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (p *Param) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	shadow := (*shadowParam)(p)
-	if err := d.DecodeElement(shadow, &start); err != nil {
+	if err := decodeElement(d, shadow, &start); err != nil {
 		return fixedSyntaxError(err, "", field, 0)
 	}
 
@@ -79,11 +90,25 @@ func (p *Param) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		return newSyntaxError(field+"/@name", locale.ErrRequired)
 	}
 
-	if shadow.Type == None {
-		return newSyntaxError(field+"/@type", locale.ErrRequired)
+	if err := checkUnion(shadow.OneOf, shadow.AnyOf, shadow.Type, shadow.Reference, shadow.Items, shadow.Enums, field); err != nil {
+		return err
 	}
-	if shadow.Type == Object && len(shadow.Items) == 0 {
-		return newSyntaxError(field+"/items", locale.ErrRequired)
+
+	// 引用类型的 type、items、enum 和约束条件均由被引用的类型提供
+	if shadow.Reference != "" {
+		if shadow.Type != None || len(shadow.Items) > 0 || len(shadow.Enums) > 0 || !shadow.Constraint.IsZero() {
+			return newSyntaxError(field+"/@ref", locale.ErrInvalidValue)
+		}
+	} else if shadow.OneOf == nil && shadow.AnyOf == nil {
+		if shadow.Type == None {
+			return newSyntaxError(field+"/@type", locale.ErrRequired)
+		}
+		if shadow.Type == Object && len(shadow.Items) == 0 {
+			return newSyntaxError(field+"/items", locale.ErrRequired)
+		}
+		if err := checkMap(shadow.Type, shadow.Items, field); err != nil {
+			return err
+		}
 	}
 
 	// 判断 enums 的值是否相同
@@ -104,7 +129,16 @@ func (p *Param) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		return err
 	}
 
-	if p.Summary == "" && p.Description.Text == "" {
+	if err := checkConstraint(shadow.Type, shadow.Array, &shadow.Constraint, field); err != nil {
+		return err
+	}
+
+	if shadow.ReadOnly && shadow.WriteOnly {
+		return newSyntaxError(field+"/@writeonly", locale.ErrInvalidValue)
+	}
+
+	// 引用类型可以从被引用的类型中获取 summary
+	if p.Summary == "" && p.Description.Text == "" && p.Reference == "" {
 		return newSyntaxError(field+"/summary", locale.ErrRequired)
 	}
 
@@ -118,25 +152,78 @@ func chkEnumsType(t Type, enums []*Enum, field string) error {
 	}
 
 	switch t {
-	case Number:
+	case Number, Float:
 		for _, enum := range enums {
 			if !is.Number(enum.Value) {
 				return newSyntaxError(field+"/enum/@"+enum.Value, locale.ErrInvalidFormat)
 			}
 		}
+	case Integer:
+		for _, enum := range enums {
+			if _, err := strconv.ParseInt(enum.Value, 10, 64); err != nil {
+				return newSyntaxError(field+"/enum/@"+enum.Value, locale.ErrInvalidFormat)
+			}
+		}
 	case Bool:
 		for _, enum := range enums {
 			if _, err := strconv.ParseBool(enum.Value); err != nil {
 				return newSyntaxError(field+"/enum/@"+enum.Value, locale.ErrInvalidFormat)
 			}
 		}
-	case Object, None:
+	case Object, Map, None:
 		return newSyntaxError(field+"/enum", locale.ErrInvalidValue)
 	}
 
 	return nil
 }
 
+// 字典类型有且只能有一个子元素，用于描述值的类型，其名称仅作为键名的说明。
+func checkMap(t Type, items []*Param, field string) error {
+	if t != Map {
+		return nil
+	}
+
+	switch len(items) {
+	case 0:
+		return newSyntaxError(field+"/param", locale.ErrRequired)
+	case 1:
+		return nil
+	default:
+		return newSyntaxError(field+"/param", locale.ErrInvalidValue)
+	}
+}
+
+// cookie 的值只能是简单的类型，且不能重名。
+//
+// 引用类型的实际类型需要在解析引用之后才能确定，此处不作检测。
+func checkCookies(cookies []*Param, field string) error {
+	for _, c := range cookies {
+		if c.Reference != "" {
+			continue
+		}
+
+		if c.Array || c.IsUnion() || c.Type == Object || c.Type == Map {
+			return newSyntaxError(field+"/cookie/@type", locale.ErrInvalidValue)
+		}
+	}
+
+	if key := getDuplicateItems(cookies); key != "" {
+		return newSyntaxError(field+"/cookie", locale.ErrDuplicateValue)
+	}
+
+	return nil
+}
+
+// MapValue 字典类型中值的类型
+//
+// 如果不是字典类型，则返回 nil。
+func (p *Param) MapValue() *Param {
+	if p.Type != Map || len(p.Items) == 0 {
+		return nil
+	}
+	return p.Items[0]
+}
+
 // 返回重复枚举的值
 func getDuplicateEnum(enums []*Enum) string {
 	if len(enums) == 0 {
@@ -97,6 +97,64 @@ func TestParam_UnmarshalXML(t *testing.T) {
 	obj1 = &Param{}
 	str = `<Param name="url" deprecated="x.1.1">text</Param>`
 	a.Error(xml.Unmarshal([]byte(str), obj1))
+
+	// 引用类型可以没有 type 和 summary
+	obj1 = &Param{}
+	str = `<Param name="user" ref="user" />`
+	a.NotError(xml.Unmarshal([]byte(str), obj1)).
+		Equal(obj1.Reference, "user").
+		Equal(obj1.Type, None)
+
+	// 引用类型不能再指定 type
+	obj1 = &Param{}
+	str = `<Param name="user" ref="user" type="string" />`
+	a.Error(xml.Unmarshal([]byte(str), obj1))
+
+	// 引用类型不能再指定约束条件
+	obj1 = &Param{}
+	str = `<Param name="user" ref="user" max-items="5" />`
+	a.Error(xml.Unmarshal([]byte(str), obj1))
+
+	// 约束条件
+	obj1 = &Param{}
+	str = `<Param name="age" type="integer" min="0" max="150" summary="age" />`
+	a.NotError(xml.Unmarshal([]byte(str), obj1))
+	a.Equal(*obj1.Min, 0).Equal(*obj1.Max, 150)
+
+	obj1 = &Param{}
+	str = `<Param name="email" type="string" format="email" max-length="50" summary="email" />`
+	a.NotError(xml.Unmarshal([]byte(str), obj1))
+	a.Equal(obj1.Format, FormatEmail).Equal(obj1.MaxLength, 50)
+
+	// 字符串不能指定 min
+	obj1 = &Param{}
+	str = `<Param name="email" type="string" min="5" summary="email" />`
+	a.Error(xml.Unmarshal([]byte(str), obj1))
+
+	// map
+	obj1 = &Param{}
+	str = `<Param name="titles" type="map" summary="titles"><param name="locale" type="string" summary="text" /></Param>`
+	a.NotError(xml.Unmarshal([]byte(str), obj1))
+	a.Equal(obj1.Type, Map).Equal(obj1.MapValue().Name, "locale")
+
+	// map 必须有且只有一个子元素
+	obj1 = &Param{}
+	str = `<Param name="titles" type="map" summary="titles"></Param>`
+	a.Error(xml.Unmarshal([]byte(str), obj1))
+	obj1 = &Param{}
+	str = `<Param name="titles" type="map" summary="titles"><param name="k1" type="string" summary="text" /><param name="k2" type="string" summary="text" /></Param>`
+	a.Error(xml.Unmarshal([]byte(str), obj1))
+
+	// nullable、readonly 和 writeonly
+	obj1 = &Param{}
+	str = `<Param name="id" type="number" readonly="true" nullable="true" summary="id" />`
+	a.NotError(xml.Unmarshal([]byte(str), obj1))
+	a.True(obj1.ReadOnly).True(obj1.Nullable).False(obj1.WriteOnly)
+
+	// readonly 和 writeonly 不能同时存在
+	obj1 = &Param{}
+	str = `<Param name="id" type="number" readonly="true" writeonly="true" summary="id" />`
+	a.Error(xml.Unmarshal([]byte(str), obj1))
 }
 
 func TestParam_UnmarshalXML_enum(t *testing.T) {
@@ -158,6 +216,11 @@ func TestChkEnumsType(t *testing.T) {
 	a.Error(chkEnumsType(Number, boolEnums, ""))
 	a.Error(chkEnumsType(Number, stringEnums, ""))
 
+	a.NotError(chkEnumsType(Integer, numberEnums, ""))
+	a.Error(chkEnumsType(Integer, []*Enum{{Value: "1.5"}}, ""))
+	a.NotError(chkEnumsType(Float, []*Enum{{Value: "1.5"}}, ""))
+	a.Error(chkEnumsType(Float, boolEnums, ""))
+
 	a.NotError(chkEnumsType(String, numberEnums, ""))
 	a.NotError(chkEnumsType(String, boolEnums, ""))
 	a.NotError(chkEnumsType(String, stringEnums, ""))
@@ -13,23 +13,37 @@ import (
 //      <param name="id" type="number" summary="summary" />
 //      <query name="page" type="number" summary="page" default="1" />
 //  </path>
+//
+// 也可以通过 ref 引用指定 ID 的 API 的路径信息，
+// 当前对象中未指定的内容，会从被引用的对象中获取：
+//  <path ref="#get-users" />
 type Path struct {
 	Path      string   `xml:"path,attr"`
 	Params    []*Param `xml:"param,omitempty"`
 	Queries   []*Param `xml:"query,omitempty"`
-	Reference string   `xml:"ref,attr,omitempty"`
+	Reference string   `xml:"ref,attr,omitempty"` // 引用 API.ID 对应的路径，可以带 # 前缀
 }
 
 type shadowPath Path
 
 // UnmarshalXML xml.Unmarshaler
-func (p *Path) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (p *Path) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	shadow := (*shadowPath)(p)
-	if err := d.DecodeElement(shadow, &start); err != nil {
+	if err := decodeElement(d, shadow, &start); err != nil {
 		return fixedSyntaxError(err, "", field, 0)
 	}
 
+	// 引用其它 API 的路径，且未指定 path，则由被引用对象提供路径参数。
+	if shadow.Reference != "" && shadow.Path == "" {
+		if len(shadow.Params) > 0 {
+			return newSyntaxError(field+"/@ref", locale.ErrInvalidValue)
+		}
+		return nil
+	}
+
 	if shadow.Path == "" {
 		return newSyntaxError(field+"/@path", locale.ErrRequired)
 	}
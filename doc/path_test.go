@@ -53,6 +53,18 @@ func TestPath_UnmarshalXML(t *testing.T) {
 	str = `<Path path="/users/{id}" ref="#get-users"></Path>`
 	a.Error(xml.Unmarshal([]byte(str), obj1))
 
+	// 仅有 ref
+	obj1 = &Path{}
+	str = `<Path ref="#get-users"><query name="text" type="string" summary="text" /></Path>`
+	a.NotError(xml.Unmarshal([]byte(str), obj1)).
+		Equal(obj1.Reference, "#get-users").
+		Equal(1, len(obj1.Queries))
+
+	// 仅有 ref，但是指定了 param
+	obj1 = &Path{}
+	str = `<Path ref="#get-users"><param name="id" type="number" summary="id" /></Path>`
+	a.Error(xml.Unmarshal([]byte(str), obj1))
+
 	// 名称不匹配
 	obj1 = &Path{}
 	str = `<Path path="/users/{id}">
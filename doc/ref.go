@@ -0,0 +1,439 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"strings"
+
+	xmessage "golang.org/x/text/message"
+
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/message"
+)
+
+// 处理文档中的 ref 引用
+//
+// param 和 request 的 ref 引用的是 Doc.Types 中的类型定义，
+// path 和 callback 的 ref 引用的是指定 ID 的 API。
+// ref 的值可以带上 # 前缀，比如 #get-users 与 get-users 是相同的。
+//
+// 引用的内容会被深度复制到引用方，各个引用方之间互不影响，
+// 同时保留 Reference 的值，方便 openapi 等输出时依然可以知道其引用关系。
+type resolver struct {
+	file string
+	line int
+
+	types map[string]*Param
+	apis  map[string]*API
+
+	// 正在解析中的对象，用于检测循环引用
+	resolving map[interface{}]bool
+
+	// 已经完成解析的类型定义
+	resolved map[*Param]bool
+}
+
+// 解析文档中的所有引用，错误信息会被添加到 c，返回值表示是否可以继续。
+func (doc *Doc) resolveReferences(c *collector) bool {
+	r := &resolver{
+		file:      doc.file,
+		line:      doc.line,
+		types:     make(map[string]*Param, len(doc.Types)),
+		apis:      make(map[string]*API, len(doc.Apis)),
+		resolving: make(map[interface{}]bool, 10),
+		resolved:  make(map[*Param]bool, len(doc.Types)),
+	}
+
+	for _, t := range doc.Types {
+		r.types[t.Name] = t
+	}
+
+	for _, api := range doc.Apis {
+		if api.ID == "" {
+			continue
+		}
+		r.apis[api.ID] = api
+	}
+
+	for _, t := range doc.Types {
+		if _, err := r.typ(t.Name, "apidoc/type"); err != nil && !c.add(err) {
+			return false
+		}
+	}
+
+	for _, resp := range doc.Responses {
+		if err := r.request(resp, "apidoc/response"); err != nil && !c.add(err) {
+			return false
+		}
+	}
+
+	for _, api := range doc.Apis {
+		r.file = api.file
+		r.line = api.line
+		if err := r.api(api, "api"); err != nil && !c.add(err) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *resolver) getType(ref string) (*Param, bool) {
+	t, found := r.types[strings.TrimPrefix(ref, "#")]
+	return t, found
+}
+
+func (r *resolver) getAPI(ref string) (*API, bool) {
+	api, found := r.apis[strings.TrimPrefix(ref, "#")]
+	return api, found
+}
+
+func (r *resolver) newError(field string, key xmessage.Reference) error {
+	return message.NewLocaleError(r.file, field, r.line, key)
+}
+
+func (r *resolver) api(api *API, field string) error {
+	if api.Path != nil {
+		if err := r.path(api.Path, field+"/path"); err != nil {
+			return err
+		}
+	}
+
+	if err := r.params(api.Headers, field+"/header"); err != nil {
+		return err
+	}
+
+	if err := r.params(api.Cookies, field+"/cookie"); err != nil {
+		return err
+	}
+
+	if err := r.requests(api.Requests, field+"/request"); err != nil {
+		return err
+	}
+
+	if err := r.requests(api.Responses, field+"/response"); err != nil {
+		return err
+	}
+
+	if api.Callback != nil {
+		return r.callback(api.Callback, field+"/callback")
+	}
+
+	return nil
+}
+
+func (r *resolver) path(p *Path, field string) error {
+	if p.Reference != "" {
+		if r.resolving[p] {
+			return r.newError(field+"/@ref", locale.ErrCircularReference)
+		}
+
+		api, found := r.getAPI(p.Reference)
+		if !found || api.Path == nil {
+			return r.newError(field+"/@ref", locale.ErrNotFound)
+		}
+
+		r.resolving[p] = true
+		err := r.path(api.Path, field)
+		delete(r.resolving, p)
+		if err != nil {
+			return err
+		}
+
+		if p.Path == "" {
+			p.Path = api.Path.Path
+			p.Params = cloneParams(api.Path.Params)
+		}
+		if len(p.Queries) == 0 {
+			p.Queries = cloneParams(api.Path.Queries)
+		}
+	}
+
+	if err := r.params(p.Params, field+"/param"); err != nil {
+		return err
+	}
+	return r.params(p.Queries, field+"/query")
+}
+
+func (r *resolver) callback(c *Callback, field string) error {
+	if c.Reference != "" {
+		api, found := r.getAPI(c.Reference)
+		if !found {
+			return r.newError(field+"/@ref", locale.ErrNotFound)
+		}
+
+		// 仅复制 API 的请求和返回等内容，不包含 API 的回调，
+		// 所以不需要对整个 API 作循环引用的检测。
+		if api.Path != nil {
+			if err := r.path(api.Path, field+"/path"); err != nil {
+				return err
+			}
+		}
+		if err := r.params(api.Headers, field+"/header"); err != nil {
+			return err
+		}
+		if err := r.params(api.Cookies, field+"/cookie"); err != nil {
+			return err
+		}
+		if err := r.requests(api.Requests, field+"/request"); err != nil {
+			return err
+		}
+		if err := r.requests(api.Responses, field+"/response"); err != nil {
+			return err
+		}
+
+		c.Method = api.Method
+		c.Path = api.Path.clone()
+		c.Headers = cloneParams(api.Headers)
+		c.Cookies = cloneParams(api.Cookies)
+		c.Requests = cloneRequests(api.Requests)
+		c.Responses = cloneRequests(api.Responses)
+		if c.Summary == "" && c.Description.Text == "" {
+			c.Summary = api.Summary
+			c.Description = api.Description
+		}
+		if c.Deprecated == "" {
+			c.Deprecated = api.Deprecated
+		}
+		return nil
+	}
+
+	if c.Path != nil {
+		if err := r.path(c.Path, field+"/path"); err != nil {
+			return err
+		}
+	}
+
+	if err := r.params(c.Headers, field+"/header"); err != nil {
+		return err
+	}
+
+	if err := r.params(c.Cookies, field+"/cookie"); err != nil {
+		return err
+	}
+
+	if err := r.requests(c.Requests, field+"/request"); err != nil {
+		return err
+	}
+
+	return r.requests(c.Responses, field+"/response")
+}
+
+func (r *resolver) requests(requests []*Request, field string) error {
+	for _, req := range requests {
+		if err := r.request(req, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *resolver) request(req *Request, field string) error {
+	if err := r.params(req.Headers, field+"/header"); err != nil {
+		return err
+	}
+
+	if err := r.params(req.Cookies, field+"/cookie"); err != nil {
+		return err
+	}
+
+	if req.Reference != "" {
+		t, err := r.typ(req.Reference, field)
+		if err != nil {
+			return err
+		}
+
+		req.Type = t.Type
+		req.Items = cloneParams(t.Items)
+		req.OneOf = t.OneOf.clone()
+		req.AnyOf = t.AnyOf.clone()
+		req.Enums = cloneEnums(t.Enums)
+		req.Constraint = t.Constraint.clone()
+		req.Array = req.Array || t.Array
+		if req.Summary == "" && req.Description.Text == "" {
+			req.Summary = t.Summary
+			req.Description = t.Description
+		}
+		return nil
+	}
+
+	if err := r.unions(req.OneOf, req.AnyOf, field); err != nil {
+		return err
+	}
+
+	return r.params(req.Items, field+"/param")
+}
+
+func (r *resolver) params(params []*Param, field string) error {
+	for _, p := range params {
+		if err := r.param(p, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *resolver) param(p *Param, field string) error {
+	if p.Reference != "" {
+		t, err := r.typ(p.Reference, field)
+		if err != nil {
+			return err
+		}
+
+		p.Type = t.Type
+		p.Items = cloneParams(t.Items)
+		p.OneOf = t.OneOf.clone()
+		p.AnyOf = t.AnyOf.clone()
+		p.Enums = cloneEnums(t.Enums)
+		p.Constraint = t.Constraint.clone()
+		p.Array = p.Array || t.Array
+		p.Nullable = p.Nullable || t.Nullable
+		p.ReadOnly = p.ReadOnly || t.ReadOnly
+		p.WriteOnly = p.WriteOnly || t.WriteOnly
+		if p.ReadOnly && p.WriteOnly {
+			return r.newError(field+"/@writeonly", locale.ErrInvalidValue)
+		}
+		if p.Summary == "" && p.Description.Text == "" {
+			p.Summary = t.Summary
+			p.Description = t.Description
+		}
+		return nil
+	}
+
+	if err := r.unions(p.OneOf, p.AnyOf, field); err != nil {
+		return err
+	}
+
+	return r.params(p.Items, field+"/param")
+}
+
+func (r *resolver) unions(oneOf, anyOf *Union, field string) error {
+	if err := r.union(oneOf, field+"/one-of"); err != nil {
+		return err
+	}
+	return r.union(anyOf, field+"/any-of")
+}
+
+func (r *resolver) union(u *Union, field string) error {
+	if u == nil {
+		return nil
+	}
+
+	if err := r.params(u.Items, field+"/param"); err != nil {
+		return err
+	}
+
+	if !u.checkDiscriminator() {
+		return r.newError(field+"/@discriminator", locale.ErrInvalidValue)
+	}
+	return nil
+}
+
+// 查找名为 name 的类型定义，并保证其内部的引用都已经被解析。
+func (r *resolver) typ(name, field string) (*Param, error) {
+	t, found := r.getType(name)
+	if !found {
+		return nil, r.newError(field+"/@ref", locale.ErrNotFound)
+	}
+
+	if r.resolved[t] {
+		return t, nil
+	}
+
+	if r.resolving[t] {
+		return nil, r.newError(field+"/@ref", locale.ErrCircularReference)
+	}
+
+	// 类型定义本身也可以是对其它类型的引用
+	r.resolving[t] = true
+	err := r.param(t, field)
+	delete(r.resolving, t)
+	if err != nil {
+		return nil, err
+	}
+
+	r.resolved[t] = true
+	return t, nil
+}
+
+func (p *Path) clone() *Path {
+	if p == nil {
+		return nil
+	}
+
+	c := *p
+	c.Params = cloneParams(p.Params)
+	c.Queries = cloneParams(p.Queries)
+	return &c
+}
+
+func cloneRequests(requests []*Request) []*Request {
+	if requests == nil {
+		return nil
+	}
+
+	rs := make([]*Request, 0, len(requests))
+	for _, r := range requests {
+		c := *r
+		c.Constraint = r.Constraint.clone()
+		c.Enums = cloneEnums(r.Enums)
+		c.Items = cloneParams(r.Items)
+		c.OneOf = r.OneOf.clone()
+		c.AnyOf = r.AnyOf.clone()
+		c.Headers = cloneParams(r.Headers)
+		c.Cookies = cloneParams(r.Cookies)
+		rs = append(rs, &c)
+	}
+	return rs
+}
+
+func cloneParams(params []*Param) []*Param {
+	if params == nil {
+		return nil
+	}
+
+	ps := make([]*Param, 0, len(params))
+	for _, p := range params {
+		c := *p
+		c.Constraint = p.Constraint.clone()
+		c.Enums = cloneEnums(p.Enums)
+		c.Items = cloneParams(p.Items)
+		c.OneOf = p.OneOf.clone()
+		c.AnyOf = p.AnyOf.clone()
+		ps = append(ps, &c)
+	}
+	return ps
+}
+
+func (u *Union) clone() *Union {
+	if u == nil {
+		return nil
+	}
+
+	return &Union{Discriminator: u.Discriminator, Items: cloneParams(u.Items)}
+}
+
+func cloneEnums(enums []*Enum) []*Enum {
+	if enums == nil {
+		return nil
+	}
+
+	es := make([]*Enum, 0, len(enums))
+	for _, e := range enums {
+		c := *e
+		es = append(es, &c)
+	}
+	return es
+}
+
+func (c Constraint) clone() Constraint {
+	if c.Min != nil {
+		min := *c.Min
+		c.Min = &min
+	}
+	if c.Max != nil {
+		max := *c.Max
+		c.Max = &max
+	}
+	return c
+}
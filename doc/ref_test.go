@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/message"
+)
+
+const refDoc = `<apidoc version="1.1.1">
+	<title>title</title>
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<mimetype>application/json</mimetype>
+
+	<type name="user" type="object" summary="user">
+		<param name="id" type="number" summary="id" />
+		<param name="name" type="string" summary="name" />
+		<param name="group" ref="#group" optional="true" />
+	</type>
+	<type name="group" type="object" summary="group">
+		<param name="id" type="number" summary="id" />
+		<param name="name" type="string" summary="name" />
+	</type>
+	<type name="users" ref="user" array="true" />
+
+	<response status="500" ref="group" />
+
+	<api method="GET" id="get-users" summary="get users">
+		<path path="/users">
+			<query name="page" type="number" summary="page" />
+		</path>
+		<server>admin</server>
+		<response status="200" ref="users" />
+	</api>
+
+	<api method="POST" id="post-users" summary="post users">
+		<path ref="#get-users" />
+		<server>admin</server>
+		<request ref="user" />
+		<response status="201" type="object" summary="user">
+			<param name="user" ref="user" summary="created user" />
+		</response>
+		<callback ref="get-users" />
+	</api>
+</apidoc>`
+
+func TestDoc_resolveReferences(t *testing.T) {
+	a := assert.New(t)
+
+	d := New()
+	a.NotError(d.FromXML("ref.xml", 1, []byte(refDoc)))
+	a.NotError(d.Sanitize())
+	a.Equal(2, len(d.Apis)).Equal(3, len(d.Types))
+
+	users := d.Types[2]
+	a.Equal(users.Type, Object).
+		True(users.Array).
+		Equal(users.Summary, "user").
+		Equal(3, len(users.Items))
+
+	group := users.Items[2]
+	a.Equal(group.Type, Object).
+		Equal(group.Reference, "#group").
+		Equal(group.Summary, "group").
+		Equal(2, len(group.Items))
+
+	a.Equal(d.Responses[0].Type, Object).
+		Equal(2, len(d.Responses[0].Items))
+
+	get := d.Apis[0]
+	a.Equal(get.ID, "get-users")
+	resp := get.Responses[0]
+	a.Equal(resp.Type, Object).
+		True(resp.Array).
+		Equal(resp.Reference, "users").
+		Equal(3, len(resp.Items))
+
+	post := d.Apis[1]
+	a.Equal(post.ID, "post-users").
+		Equal(post.Path.Path, "/users").
+		Equal(1, len(post.Path.Queries))
+	req := post.Requests[0]
+	a.Equal(req.Type, Object).False(req.Array).Equal(3, len(req.Items))
+	user := post.Responses[0].Items[0]
+	a.Equal(user.Type, Object).
+		Equal(user.Summary, "created user").
+		Equal(3, len(user.Items))
+
+	cb := post.Callback
+	a.Equal(cb.Method, "GET").
+		Equal(cb.Summary, "get users").
+		Equal(cb.Path.Path, "/users").
+		Equal(1, len(cb.Responses))
+
+	// 多次调用不会出错
+	a.NotError(d.Sanitize())
+}
+
+func TestDoc_resolveReferences_clone(t *testing.T) {
+	a := assert.New(t)
+
+	d := New()
+	a.NotError(d.FromXML("ref.xml", 1, []byte(refDoc)))
+	a.NotError(d.Sanitize())
+
+	// 引用方之间不共享内容
+	user := d.Types[0]
+	req := d.Apis[1].Requests[0]
+	resp := d.Apis[1].Responses[0].Items[0]
+	a.Equal(3, len(req.Items)).Equal(3, len(resp.Items))
+	req.Items = req.Items[:1]
+	resp.Items[0].Name = "uid"
+	a.Equal(3, len(user.Items)).
+		Equal(user.Items[0].Name, "id").
+		Equal(d.Apis[0].Responses[0].Items[0].Name, "id")
+
+	// 通过 callback 引用的 API
+	cb := d.Apis[1].Callback
+	cb.Path.Queries = nil
+	a.Equal(1, len(d.Apis[0].Path.Queries))
+}
+
+func TestDoc_resolveReferences_flags(t *testing.T) {
+	a := assert.New(t)
+
+	d := New()
+	d.Types = []*Param{
+		{Name: "id", Type: Number, Summary: "id", ReadOnly: true},
+		{Name: "password", Type: String, Summary: "password", WriteOnly: true, Nullable: true},
+		{Name: "user", Type: Object, Summary: "user", Items: []*Param{
+			{Name: "id", Reference: "id"},
+			{Name: "password", Reference: "password"},
+			{Name: "name", Type: String, Summary: "name", Nullable: true},
+		}},
+	}
+	a.NotError(d.Sanitize())
+
+	user := d.Types[2]
+	a.True(user.Items[0].ReadOnly).False(user.Items[0].WriteOnly).False(user.Items[0].Nullable)
+	a.True(user.Items[1].WriteOnly).True(user.Items[1].Nullable).False(user.Items[1].ReadOnly)
+
+	// 引用方与被引用方的 readonly 和 writeonly 冲突
+	d = New()
+	d.Types = []*Param{
+		{Name: "id", Type: Number, Summary: "id", ReadOnly: true},
+		{Name: "user", Type: Object, Summary: "user", Items: []*Param{
+			{Name: "id", Reference: "id", WriteOnly: true},
+		}},
+	}
+	err := d.Sanitize()
+	serr, ok := err.(*message.SyntaxError)
+	a.True(ok).NotNil(serr)
+	a.Equal(serr.Field, "apidoc/type/param/@writeonly")
+}
+
+func TestDoc_resolveReferences_error(t *testing.T) {
+	a := assert.New(t)
+
+	// 找不到引用的类型
+	d := New()
+	d.Apis = []*API{
+		{
+			file:    "api.go",
+			line:    11,
+			doc:     d,
+			Method:  "GET",
+			Path:    &Path{Path: "/users"},
+			Servers: []string{"admin"},
+			Requests: []*Request{
+				{Items: []*Param{{Name: "user", Reference: "not-exists"}}},
+			},
+		},
+	}
+	err := d.Sanitize()
+	serr, ok := err.(*message.SyntaxError)
+	a.True(ok).NotNil(serr)
+	a.Equal(serr.File, "api.go").
+		Equal(serr.Line, 11).
+		Equal(serr.Field, "api/request/param/@ref")
+
+	// 类型之间的循环引用
+	d = New()
+	d.file = "doc.go"
+	d.line = 5
+	d.Types = []*Param{
+		{Name: "t1", Type: Object, Items: []*Param{{Name: "t2", Reference: "t2"}}},
+		{Name: "t2", Type: Object, Items: []*Param{{Name: "t1", Reference: "t1"}}},
+	}
+	err = d.Sanitize()
+	serr, ok = err.(*message.SyntaxError)
+	a.True(ok).NotNil(serr)
+	a.Equal(serr.File, "doc.go").Equal(serr.Line, 5)
+
+	// 引用自身
+	d = New()
+	d.Types = []*Param{{Name: "t1", Reference: "t1"}}
+	a.Error(d.Sanitize())
+
+	// 路径的循环引用
+	d = New()
+	d.Apis = []*API{
+		{ID: "a1", doc: d, Method: "GET", Path: &Path{Reference: "a2"}},
+		{ID: "a2", doc: d, Method: "GET", Path: &Path{Reference: "#a1"}},
+	}
+	a.Error(d.Sanitize())
+
+	// 找不到引用的 API
+	d = New()
+	d.Apis = []*API{
+		{ID: "a1", doc: d, Method: "GET", Path: &Path{Path: "/"}, Callback: &Callback{Reference: "a2"}},
+	}
+	a.Error(d.Sanitize())
+}
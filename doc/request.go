@@ -11,6 +11,7 @@ import (
 // Request 请求内容
 type Request struct {
 	XML
+	Constraint
 
 	// 一般无用，但是用于描述 XML 对象时，可以用来表示顶层元素的名称
 	Name string `xml:"name,attr,omitempty"`
@@ -20,12 +21,15 @@ type Request struct {
 	Enums       []*Enum    `xml:"enum,omitempty"`
 	Array       bool       `xml:"array,attr,omitempty"`
 	Items       []*Param   `xml:"param,omitempty"`
-	Reference   string     `xml:"ref,attr,omitempty"`
+	Reference   string     `xml:"ref,attr,omitempty"` // 引用 doc.Types 中的类型
+	OneOf       *Union     `xml:"one-of,omitempty"`   // 值只能匹配其中一个子类型
+	AnyOf       *Union     `xml:"any-of,omitempty"`   // 值至少匹配其中一个子类型
 	Summary     string     `xml:"summary,attr,omitempty"`
 	Status      Status     `xml:"status,attr,omitempty"`
 	Mimetype    string     `xml:"mimetype,attr,omitempty"`
 	Examples    []*Example `xml:"example,omitempty"`
 	Headers     []*Param   `xml:"header,omitempty"` // 当前独有的报头，公用的可以放在 API 中
+	Cookies     []*Param   `xml:"cookie,omitempty"` // 请求时表示需要提交的 cookie，返回时表示需要设置的 cookie
 	Description Richtext   `xml:"description,omitempty"`
 }
 
@@ -37,15 +41,27 @@ func (r *Request) IsEnum() bool {
 type shadowRequest Request
 
 // UnmarshalXML xml.Unmarshaler
-func (r *Request) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (r *Request) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	shadow := (*shadowRequest)(r)
-	if err := d.DecodeElement(shadow, &start); err != nil {
+	if err := decodeElement(d, shadow, &start); err != nil {
 		return fixedSyntaxError(err, "", field, 0)
 	}
 
-	if shadow.Type == Object && len(shadow.Items) == 0 {
+	if err := checkUnion(shadow.OneOf, shadow.AnyOf, shadow.Type, shadow.Reference, shadow.Items, shadow.Enums, field); err != nil {
+		return err
+	}
+
+	if shadow.Reference != "" {
+		if shadow.Type != None || len(shadow.Items) > 0 || len(shadow.Enums) > 0 || !shadow.Constraint.IsZero() {
+			return newSyntaxError(field+"/@ref", locale.ErrInvalidValue)
+		}
+	} else if shadow.Type == Object && len(shadow.Items) == 0 {
 		return newSyntaxError(field+"/param", locale.ErrRequired)
+	} else if err := checkMap(shadow.Type, shadow.Items, field); err != nil {
+		return err
 	}
 
 	// 判断 enums 的值是否相同
@@ -61,6 +77,10 @@ func (r *Request) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		return err
 	}
 
+	if err := checkConstraint(shadow.Type, shadow.Array, &shadow.Constraint, field); err != nil {
+		return err
+	}
+
 	if shadow.Mimetype != "" {
 		for _, exp := range shadow.Examples {
 			if exp.Mimetype != shadow.Mimetype {
@@ -74,5 +94,9 @@ func (r *Request) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		return newSyntaxError(field+"/param", locale.ErrDuplicateValue)
 	}
 
+	if err := checkCookies(shadow.Cookies, field); err != nil {
+		return err
+	}
+
 	return nil
 }
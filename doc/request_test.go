@@ -72,6 +72,25 @@ func TestRequest_UnmarshalXML(t *testing.T) {
 	obj1 = &Request{}
 	str = `<Request deprecated="x.1.1" mimetype="json">text</Request>`
 	a.Error(xml.Unmarshal([]byte(str), obj1))
+
+	// cookie
+	obj1 = &Request{}
+	str = `<Request type="string"><cookie name="session" type="string" summary="session" /></Request>`
+	a.NotError(xml.Unmarshal([]byte(str), obj1))
+	a.Equal(obj1.Cookies[0].Name, "session")
+
+	// cookie 只能是简单类型
+	obj1 = &Request{}
+	str = `<Request type="string"><cookie name="session" type="string" array="true" summary="session" /></Request>`
+	a.Error(xml.Unmarshal([]byte(str), obj1))
+
+	// cookie 重名
+	obj1 = &Request{}
+	str = `<Request type="string">
+		<cookie name="session" type="string" summary="session" />
+		<cookie name="session" type="number" summary="session" />
+	</Request>`
+	a.Error(xml.Unmarshal([]byte(str), obj1))
 }
 
 func TestRequest_UnmarshalXML_enum(t *testing.T) {
@@ -32,11 +32,13 @@ func (text Richtext) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 }
 
 // UnmarshalXML 实现 xml.Unmarshaler
-func (text *Richtext) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (text *Richtext) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 
 	shadow := (*shadowRichtext)(text)
-	if err := d.DecodeElement(shadow, &start); err != nil {
+	if err := decodeElement(d, shadow, &start); err != nil {
 		return fixedSyntaxError(err, "", field, 0)
 	}
 
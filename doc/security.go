@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"encoding/xml"
+
+	"github.com/issue9/is"
+
+	"github.com/caixw/apidoc/v6/internal/locale"
+)
+
+// Security.Type 的可选值
+const (
+	SecurityAPIKey        = "apikey"
+	SecurityHTTP          = "http"
+	SecurityOAuth2        = "oauth2"
+	SecurityOpenIDConnect = "openidconnect"
+)
+
+// Security.IN 的可选值
+const (
+	SecurityInHeader = "header"
+	SecurityInQuery  = "query"
+	SecurityInCookie = "cookie"
+)
+
+// OAuthFlow.Type 的可选值
+const (
+	FlowImplicit          = "implicit"
+	FlowPassword          = "password"
+	FlowClientCredentials = "clientCredentials"
+	FlowAuthorizationCode = "authorizationCode"
+)
+
+// Security 身份验证方案
+//  <security name="token" type="apikey" in="header" key="X-Token" summary="token" />
+//  <security name="jwt" type="http" scheme="bearer" bearer-format="JWT" />
+//  <security name="oauth" type="oauth2">
+//      <flow type="authorizationCode" authorization-url="https://example.com/auth" token-url="https://example.com/token">
+//          <scope name="read:users" summary="read users" />
+//      </flow>
+//  </security>
+//  <security name="oidc" type="openidconnect" url="https://example.com/.well-known/openid-configuration" />
+type Security struct {
+	Name        string   `xml:"name,attr"` // 唯一名称，供 API 引用
+	Type        string   `xml:"type,attr"`
+	Summary     string   `xml:"summary,attr,omitempty"`
+	Description Richtext `xml:"description,omitempty"`
+
+	// apikey
+	IN  string `xml:"in,attr,omitempty"`  // 凭证所在的位置，可以是 header、query 和 cookie
+	Key string `xml:"key,attr,omitempty"` // 报头、查询参数或是 cookie 的名称
+
+	// http
+	Scheme       string `xml:"scheme,attr,omitempty"` // 比如 basic、bearer 等
+	BearerFormat string `xml:"bearer-format,attr,omitempty"`
+
+	// oauth2
+	Flows []*OAuthFlow `xml:"flow,omitempty"`
+
+	// openidconnect
+	URL string `xml:"url,attr,omitempty"`
+}
+
+// OAuthFlow oauth2 的授权流程
+type OAuthFlow struct {
+	Type             string   `xml:"type,attr"`
+	AuthorizationURL string   `xml:"authorization-url,attr,omitempty"`
+	TokenURL         string   `xml:"token-url,attr,omitempty"`
+	RefreshURL       string   `xml:"refresh-url,attr,omitempty"`
+	Scopes           []*Scope `xml:"scope,omitempty"`
+}
+
+// Scope oauth2 的授权范围
+type Scope struct {
+	Name    string `xml:"name,attr"`
+	Summary string `xml:"summary,attr"`
+}
+
+// SecurityRequirement API 需要满足的验证方案
+//  <security name="oauth">
+//      <scope>read:users</scope>
+//  </security>
+//
+// 同一个 API 中的多个 security 之间是或的关系，满足其中之一即可。
+type SecurityRequirement struct {
+	Name   string   `xml:"name,attr"` // 引用 Doc.Security 中的名称
+	Scopes []string `xml:"scope,omitempty"`
+}
+
+type (
+	shadowSecurity            Security
+	shadowOAuthFlow           OAuthFlow
+	shadowScope               Scope
+	shadowSecurityRequirement SecurityRequirement
+)
+
+// UnmarshalXML xml.Unmarshaler
+func (s *Security) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
+	field := "/" + start.Name.Local
+	shadow := (*shadowSecurity)(s)
+	if err := decodeElement(d, shadow, &start); err != nil {
+		return fixedSyntaxError(err, "", field, 0)
+	}
+
+	if shadow.Name == "" {
+		return newSyntaxError(field+"/@name", locale.ErrRequired)
+	}
+
+	switch shadow.Type {
+	case SecurityAPIKey:
+		switch shadow.IN {
+		case SecurityInHeader, SecurityInQuery, SecurityInCookie:
+		case "":
+			return newSyntaxError(field+"/@in", locale.ErrRequired)
+		default:
+			return newSyntaxError(field+"/@in", locale.ErrInvalidValue)
+		}
+
+		if shadow.Key == "" {
+			return newSyntaxError(field+"/@key", locale.ErrRequired)
+		}
+	case SecurityHTTP:
+		if shadow.Scheme == "" {
+			return newSyntaxError(field+"/@scheme", locale.ErrRequired)
+		}
+	case SecurityOAuth2:
+		if len(shadow.Flows) == 0 {
+			return newSyntaxError(field+"/flow", locale.ErrRequired)
+		}
+
+		types := make([]string, 0, len(shadow.Flows))
+		for _, flow := range shadow.Flows {
+			types = append(types, flow.Type)
+		}
+		if key := findDupString(types); key != "" {
+			return newSyntaxError(field+"/flow/@type", locale.ErrDuplicateValue)
+		}
+	case SecurityOpenIDConnect:
+		if !is.URL(shadow.URL) {
+			return newSyntaxError(field+"/@url", locale.ErrInvalidFormat)
+		}
+	case "":
+		return newSyntaxError(field+"/@type", locale.ErrRequired)
+	default:
+		return newSyntaxError(field+"/@type", locale.ErrInvalidValue)
+	}
+
+	return nil
+}
+
+// UnmarshalXML xml.Unmarshaler
+func (f *OAuthFlow) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
+	field := "/" + start.Name.Local
+	shadow := (*shadowOAuthFlow)(f)
+	if err := decodeElement(d, shadow, &start); err != nil {
+		return fixedSyntaxError(err, "", field, 0)
+	}
+
+	var authURL, tokenURL bool
+	switch shadow.Type {
+	case FlowImplicit:
+		authURL = true
+	case FlowPassword, FlowClientCredentials:
+		tokenURL = true
+	case FlowAuthorizationCode:
+		authURL = true
+		tokenURL = true
+	case "":
+		return newSyntaxError(field+"/@type", locale.ErrRequired)
+	default:
+		return newSyntaxError(field+"/@type", locale.ErrInvalidValue)
+	}
+
+	if authURL && !is.URL(shadow.AuthorizationURL) {
+		return newSyntaxError(field+"/@authorization-url", locale.ErrInvalidFormat)
+	}
+
+	if tokenURL && !is.URL(shadow.TokenURL) {
+		return newSyntaxError(field+"/@token-url", locale.ErrInvalidFormat)
+	}
+
+	if shadow.RefreshURL != "" && !is.URL(shadow.RefreshURL) {
+		return newSyntaxError(field+"/@refresh-url", locale.ErrInvalidFormat)
+	}
+
+	names := make([]string, 0, len(shadow.Scopes))
+	for _, scope := range shadow.Scopes {
+		names = append(names, scope.Name)
+	}
+	if key := findDupString(names); key != "" {
+		return newSyntaxError(field+"/scope/@name", locale.ErrDuplicateValue)
+	}
+
+	return nil
+}
+
+// UnmarshalXML xml.Unmarshaler
+func (s *Scope) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
+	field := "/" + start.Name.Local
+	shadow := (*shadowScope)(s)
+	if err := decodeElement(d, shadow, &start); err != nil {
+		return fixedSyntaxError(err, "", field, 0)
+	}
+
+	if shadow.Name == "" {
+		return newSyntaxError(field+"/@name", locale.ErrRequired)
+	}
+
+	if shadow.Summary == "" {
+		return newSyntaxError(field+"/@summary", locale.ErrRequired)
+	}
+
+	return nil
+}
+
+// UnmarshalXML xml.Unmarshaler
+func (s *SecurityRequirement) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
+	field := "/" + start.Name.Local
+	shadow := (*shadowSecurityRequirement)(s)
+	if err := decodeElement(d, shadow, &start); err != nil {
+		return fixedSyntaxError(err, "", field, 0)
+	}
+
+	if shadow.Name == "" {
+		return newSyntaxError(field+"/@name", locale.ErrRequired)
+	}
+
+	return nil
+}
+
+// 查找是否有重复的 Security 名称
+func findDupSecurity(securities []*Security) string {
+	names := make([]string, 0, len(securities))
+	for _, s := range securities {
+		names = append(names, s.Name)
+	}
+	return findDupString(names)
+}
+
+// 查找名为 name 的验证方案
+func (doc *Doc) getSecurity(name string) *Security {
+	for _, s := range doc.Security {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// 是否存在名为 name 的 scope
+func (s *Security) scopeExists(name string) bool {
+	for _, flow := range s.Flows {
+		for _, scope := range flow.Scopes {
+			if scope.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
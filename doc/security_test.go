@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/issue9/assert"
+)
+
+var (
+	_ xml.Unmarshaler = &Security{}
+	_ xml.Unmarshaler = &OAuthFlow{}
+	_ xml.Unmarshaler = &Scope{}
+	_ xml.Unmarshaler = &SecurityRequirement{}
+)
+
+func TestSecurity_UnmarshalXML(t *testing.T) {
+	a := assert.New(t)
+
+	obj := &Security{}
+	str := `<security name="token" type="apikey" in="query" key="token" />`
+	a.NotError(xml.Unmarshal([]byte(str), obj))
+	a.Equal(obj.Type, SecurityAPIKey).
+		Equal(obj.IN, SecurityInQuery).
+		Equal(obj.Key, "token")
+
+	obj = &Security{}
+	str = `<security name="oauth" type="oauth2">
+		<flow type="implicit" authorization-url="https://example.com/auth">
+			<scope name="read" summary="read" />
+		</flow>
+		<flow type="password" token-url="https://example.com/token" />
+	</security>`
+	a.NotError(xml.Unmarshal([]byte(str), obj))
+	a.Equal(2, len(obj.Flows)).
+		Equal(obj.Flows[0].Scopes[0].Name, "read")
+
+	data := []string{
+		// 少 name
+		`<security type="http" scheme="basic" />`,
+		// 少 type
+		`<security name="s1" />`,
+		// 无效的 type
+		`<security name="s1" type="not-exists" />`,
+		// apikey 少 in
+		`<security name="s1" type="apikey" key="token" />`,
+		// apikey 无效的 in
+		`<security name="s1" type="apikey" in="body" key="token" />`,
+		// apikey 少 key
+		`<security name="s1" type="apikey" in="header" />`,
+		// http 少 scheme
+		`<security name="s1" type="http" />`,
+		// oauth2 少 flow
+		`<security name="s1" type="oauth2" />`,
+		// 重复的 flow
+		`<security name="s1" type="oauth2">
+			<flow type="password" token-url="https://example.com/token" />
+			<flow type="password" token-url="https://example.com/token" />
+		</security>`,
+		// openidconnect 无效的 url
+		`<security name="s1" type="openidconnect" url="not-url" />`,
+	}
+	for index, str := range data {
+		a.Error(xml.Unmarshal([]byte(str), &Security{}), "not error at %d", index)
+	}
+}
+
+func TestOAuthFlow_UnmarshalXML(t *testing.T) {
+	a := assert.New(t)
+
+	obj := &OAuthFlow{}
+	str := `<flow type="authorizationCode" authorization-url="https://example.com/auth" token-url="https://example.com/token" refresh-url="https://example.com/refresh" />`
+	a.NotError(xml.Unmarshal([]byte(str), obj))
+	a.Equal(obj.RefreshURL, "https://example.com/refresh")
+
+	data := []string{
+		`<flow />`,
+		`<flow type="not-exists" />`,
+		`<flow type="implicit" />`,
+		`<flow type="clientCredentials" authorization-url="https://example.com/auth" />`,
+		`<flow type="authorizationCode" authorization-url="https://example.com/auth" />`,
+		`<flow type="password" token-url="https://example.com/token" refresh-url="not-url" />`,
+		`<flow type="password" token-url="https://example.com/token">
+			<scope name="read" summary="read" />
+			<scope name="read" summary="read" />
+		</flow>`,
+	}
+	for index, str := range data {
+		a.Error(xml.Unmarshal([]byte(str), &OAuthFlow{}), "not error at %d", index)
+	}
+}
+
+func TestScope_UnmarshalXML(t *testing.T) {
+	a := assert.New(t)
+
+	obj := &Scope{}
+	a.NotError(xml.Unmarshal([]byte(`<scope name="read" summary="read" />`), obj))
+	a.Equal(obj, &Scope{Name: "read", Summary: "read"})
+
+	a.Error(xml.Unmarshal([]byte(`<scope summary="read" />`), &Scope{}))
+	a.Error(xml.Unmarshal([]byte(`<scope name="read" />`), &Scope{}))
+}
+
+func TestSecurityRequirement_UnmarshalXML(t *testing.T) {
+	a := assert.New(t)
+
+	obj := &SecurityRequirement{}
+	str := `<security name="oauth"><scope>read</scope><scope>write</scope></security>`
+	a.NotError(xml.Unmarshal([]byte(str), obj))
+	a.Equal(obj, &SecurityRequirement{Name: "oauth", Scopes: []string{"read", "write"}})
+
+	a.Error(xml.Unmarshal([]byte(`<security />`), &SecurityRequirement{}))
+}
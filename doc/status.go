@@ -36,7 +36,9 @@ func (s *Status) UnmarshalXMLAttr(attr xml.Attr) error {
 }
 
 // UnmarshalXML xml.Unmarshaler
-func (s *Status) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (s *Status) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	var v int
 	if err := d.DecodeElement(&v, &start); err != nil {
@@ -35,10 +35,12 @@ type (
 )
 
 // UnmarshalXML xml.Unmarshaler
-func (t *Tag) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (t *Tag) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	var shadow shadowTag
-	if err := d.DecodeElement(&shadow, &start); err != nil {
+	if err := decodeElement(d, &shadow, &start); err != nil {
 		return fixedSyntaxError(err, "", field, 0)
 	}
 
@@ -55,10 +57,12 @@ func (t *Tag) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 }
 
 // UnmarshalXML xml.Unmarshaler
-func (srv *Server) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (srv *Server) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	var shadow shadowServer
-	if err := d.DecodeElement(&shadow, &start); err != nil {
+	if err := decodeElement(d, &shadow, &start); err != nil {
 		return fixedSyntaxError(err, "", field, 0)
 	}
 
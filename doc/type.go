@@ -14,17 +14,20 @@ type Type string
 
 // 表示支持的各种数据类型
 const (
-	None   Type = ""
-	Bool        = "bool"
-	Object      = "object"
-	Number      = "number"
-	String      = "string"
+	None    Type = ""
+	Bool         = "bool"
+	Object       = "object"
+	Map          = "map"    // 键名为字符串的字典，值的类型由唯一的子元素指定
+	Number       = "number" // 任意数值，整数或是浮点数
+	Integer      = "integer"
+	Float        = "float"
+	String       = "string"
 )
 
 func parseType(val string) (Type, error) {
 	val = strings.ToLower(val)
 	switch Type(val) {
-	case None, Bool, Object, Number, String:
+	case None, Bool, Object, Map, Number, Integer, Float, String:
 		return Type(val), nil
 	default:
 		return None, locale.Errorf(locale.ErrInvalidFormat)
@@ -35,7 +38,7 @@ func parseType(val string) (Type, error) {
 func (t *Type) UnmarshalXMLAttr(attr xml.Attr) error {
 	v, err := parseType(attr.Value)
 	if err != nil {
-		return err
+		return fixedSyntaxError(err, "", "/@"+attr.Name.Local, 0)
 	}
 
 	*t = v
@@ -43,7 +46,9 @@ func (t *Type) UnmarshalXMLAttr(attr xml.Attr) error {
 }
 
 // UnmarshalXML xml.Unmarshaler
-func (t *Type) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (t *Type) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	var str string
 	if err := d.DecodeElement(&str, &start); err != nil {
@@ -86,7 +91,10 @@ func (t Type) fmtString() (string, error) {
 	valid := t == None ||
 		t == Bool ||
 		t == Object ||
+		t == Map ||
 		t == Number ||
+		t == Integer ||
+		t == Float ||
 		t == String
 
 	if valid {
@@ -94,3 +102,8 @@ func (t Type) fmtString() (string, error) {
 	}
 	return "", locale.Errorf(locale.ErrInvalidValue)
 }
+
+// IsNumber 是否为数值类型
+func (t Type) IsNumber() bool {
+	return t == Number || t == Integer || t == Float
+}
@@ -50,6 +50,16 @@ func TestTypeXML(t *testing.T) {
 	str = `<type attr="string"><value>`
 	a.Error(xml.Unmarshal([]byte(str), obj1))
 
+	// integer 和 float
+	str = `<type attr="integer"><value>float</value></type>`
+	a.NotError(xml.Unmarshal([]byte(str), obj1))
+	a.Equal(obj1.Attr, Integer).Equal(obj1.Value, Float)
+
+	// map
+	str = `<type attr="map"><value>map</value></type>`
+	a.NotError(xml.Unmarshal([]byte(str), obj1))
+	a.Equal(obj1.Attr, Map).Equal(obj1.Value, Map)
+
 	// fmt
 	obj.Value = Type("100")
 	data, err = xml.Marshal(obj)
@@ -79,3 +89,13 @@ func TestType_None(t *testing.T) {
 	a.NotError(xml.Unmarshal([]byte(str), obj1))
 	a.Equal(obj, obj1)
 }
+
+func TestType_IsNumber(t *testing.T) {
+	a := assert.New(t)
+
+	a.True(Type(Number).IsNumber()).
+		True(Type(Integer).IsNumber()).
+		True(Type(Float).IsNumber()).
+		False(Type(String).IsNumber()).
+		False(None.IsNumber())
+}
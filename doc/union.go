@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"encoding/xml"
+
+	"github.com/caixw/apidoc/v6/internal/locale"
+)
+
+// Union 表示值可以是多个类型中的一种
+//  <param name="payment" summary="payment">
+//      <one-of discriminator="type">
+//          <param name="card" ref="card" />
+//          <param name="bank" type="object" summary="bank">
+//              <param name="type" type="string" summary="type" />
+//              <param name="account" type="string" summary="account" />
+//          </param>
+//      </one-of>
+//  </param>
+//
+// 子类型的 name 仅用于区分各个子类型，在指定了 discriminator 时，
+// 同时也表示 discriminator 属性对应的值。
+// 所以指定 discriminator 时，所有的子类型都必须是包含该 string 属性的对象。
+type Union struct {
+	Discriminator string   `xml:"discriminator,attr,omitempty"` // 用于区分子类型的属性名称
+	Items         []*Param `xml:"param"`
+}
+
+type shadowUnion Union
+
+// IsUnion 是否为多个类型的组合
+func (p *Param) IsUnion() bool {
+	return p.OneOf != nil || p.AnyOf != nil
+}
+
+// IsUnion 是否为多个类型的组合
+func (r *Request) IsUnion() bool {
+	return r.OneOf != nil || r.AnyOf != nil
+}
+
+// UnmarshalXML xml.Unmarshaler
+func (u *Union) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
+	field := "/" + start.Name.Local
+	shadow := (*shadowUnion)(u)
+	if err := decodeElement(d, shadow, &start); err != nil {
+		return fixedSyntaxError(err, "", field, 0)
+	}
+
+	if len(shadow.Items) == 0 {
+		return newSyntaxError(field+"/param", locale.ErrRequired)
+	}
+
+	if key := getDuplicateItems(shadow.Items); key != "" {
+		return newSyntaxError(field+"/param", locale.ErrDuplicateValue)
+	}
+
+	return nil
+}
+
+// 检测 oneOf 和 anyOf 与其它属性是否冲突
+//
+// 组合类型的实际类型由各个子类型决定，不能再指定 type、ref、子元素和枚举值。
+func checkUnion(oneOf, anyOf *Union, t Type, ref string, items []*Param, enums []*Enum, field string) error {
+	if oneOf == nil && anyOf == nil {
+		return nil
+	}
+
+	if oneOf != nil && anyOf != nil {
+		return newSyntaxError(field+"/any-of", locale.ErrInvalidValue)
+	}
+
+	if t != None || ref != "" || len(items) > 0 || len(enums) > 0 {
+		if oneOf != nil {
+			return newSyntaxError(field+"/one-of", locale.ErrInvalidValue)
+		}
+		return newSyntaxError(field+"/any-of", locale.ErrInvalidValue)
+	}
+
+	return nil
+}
+
+// 检测子类型是否都包含 discriminator 指定的属性
+//
+// 子类型可能是引用，所以只能在引用解析完之后才能检测。
+func (u *Union) checkDiscriminator() bool {
+	if u.Discriminator == "" {
+		return true
+	}
+
+	for _, item := range u.Items {
+		if item.Type != Object || item.Array {
+			return false
+		}
+
+		found := false
+		for _, p := range item.Items {
+			if p.Name == u.Discriminator && p.Type == String && !p.Array {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
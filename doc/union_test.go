@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+
+package doc
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/issue9/assert"
+)
+
+const unionDoc = `<apidoc version="1.1.1">
+	<title>title</title>
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<mimetype>application/json</mimetype>
+
+	<type name="card" type="object" summary="card">
+		<param name="type" type="string" summary="type" />
+		<param name="number" type="string" summary="number" />
+	</type>
+
+	<api method="POST" summary="pay">
+		<path path="/payments" />
+		<server>admin</server>
+		<request>
+			<one-of discriminator="type">
+				<param name="card" ref="card" />
+				<param name="bank" type="object" summary="bank">
+					<param name="type" type="string" summary="type" />
+					<param name="account" type="string" summary="account" />
+				</param>
+			</one-of>
+		</request>
+		<response status="200" type="object" summary="result">
+			<param name="id" summary="id">
+				<any-of>
+					<param name="n" type="number" summary="number id" />
+					<param name="s" type="string" summary="string id" />
+				</any-of>
+			</param>
+		</response>
+	</api>
+</apidoc>`
+
+func TestUnion(t *testing.T) {
+	a := assert.New(t)
+
+	d := New()
+	a.NotError(d.FromXML("union.xml", 1, []byte(unionDoc)))
+	a.NotError(d.Sanitize())
+
+	req := d.Apis[0].Requests[0]
+	a.True(req.IsUnion()).
+		Equal(req.Type, None).
+		Equal(req.OneOf.Discriminator, "type").
+		Equal(2, len(req.OneOf.Items))
+	card := req.OneOf.Items[0]
+	a.Equal(card.Type, Object).Equal(2, len(card.Items))
+
+	id := d.Apis[0].Responses[0].Items[0]
+	a.True(id.IsUnion()).Nil(id.OneOf).Equal(2, len(id.AnyOf.Items))
+
+	// 引用的类型中不包含 discriminator 指定的属性
+	d = New()
+	data := []byte(`<apidoc version="1.1.1">
+	<title>title</title>
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<mimetype>application/json</mimetype>
+	<type name="card" type="object" summary="card">
+		<param name="number" type="string" summary="number" />
+	</type>
+	<response status="500">
+		<one-of discriminator="type">
+			<param name="card" ref="card" />
+		</one-of>
+	</response>
+</apidoc>`)
+	a.NotError(d.FromXML("union.xml", 1, data))
+	a.Error(d.Sanitize())
+}
+
+func TestUnion_UnmarshalXML(t *testing.T) {
+	a := assert.New(t)
+
+	obj := &Param{}
+	str := `<Param name="id" summary="id"><one-of><param name="n" type="number" summary="n" /></one-of></Param>`
+	a.NotError(xml.Unmarshal([]byte(str), obj))
+	a.True(obj.IsUnion()).Equal(1, len(obj.OneOf.Items))
+
+	// 没有子类型
+	obj = &Param{}
+	str = `<Param name="id" summary="id"><one-of></one-of></Param>`
+	a.Error(xml.Unmarshal([]byte(str), obj))
+
+	// 子类型的名称重复
+	obj = &Param{}
+	str = `<Param name="id" summary="id"><one-of><param name="n" type="number" summary="n" /><param name="n" type="string" summary="n" /></one-of></Param>`
+	a.Error(xml.Unmarshal([]byte(str), obj))
+
+	// 不能同时指定 type
+	obj = &Param{}
+	str = `<Param name="id" type="string" summary="id"><one-of><param name="n" type="number" summary="n" /></one-of></Param>`
+	a.Error(xml.Unmarshal([]byte(str), obj))
+
+	// 不能同时指定 one-of 和 any-of
+	obj = &Param{}
+	str = `<Param name="id" summary="id"><one-of><param name="n" type="number" summary="n" /></one-of><any-of><param name="n" type="number" summary="n" /></any-of></Param>`
+	a.Error(xml.Unmarshal([]byte(str), obj))
+
+	// request
+	req := &Request{}
+	str = `<Request ref="user"><any-of><param name="n" type="number" summary="n" /></any-of></Request>`
+	a.Error(xml.Unmarshal([]byte(str), req))
+}
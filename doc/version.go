@@ -25,7 +25,9 @@ func (v *Version) UnmarshalXMLAttr(attr xml.Attr) error {
 }
 
 // UnmarshalXML xml.Unmarshaler
-func (v *Version) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+func (v *Version) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	defer collectError(d, start)(&err)
+
 	field := "/" + start.Name.Local
 	var str string
 	if err := d.DecodeElement(&str, &start); err != nil {
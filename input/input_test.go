@@ -40,6 +40,14 @@ func TestParse(t *testing.T) {
 	a.Empty(erro.String())
 }
 
+func TestMaxErrors(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(0, maxErrors())
+	a.Equal(10, maxErrors(&Options{MaxErrors: 5}, &Options{MaxErrors: 10}))
+	a.Equal(-1, maxErrors(&Options{MaxErrors: 5}, &Options{MaxErrors: -1}))
+}
+
 func TestReadFile(t *testing.T) {
 	a := assert.New(t)
 
@@ -36,11 +36,20 @@ type Options struct {
 	// 源文件的编码，默认为 UTF-8
 	Encoding string `yaml:"encoding,omitempty"`
 
+	// 每个注释块最多报告的语法错误数量
+	//
+	// 为 0 时采用默认值 10；小于 0 表示不限制数量。
+	// 注意与 doc.Doc 的默认值不同，doc.New() 返回的对象默认遇到第一个错误即返回。
+	MaxErrors int `yaml:"max-errors,omitempty"`
+
 	blocks   []lang.Blocker    // 根据 Lang 生成
 	paths    []string          // 根据 Dir、Exts 和 Recursive 生成
 	encoding encoding.Encoding // 根据 Encoding 生成
 }
 
+// MaxErrors 的默认值
+const defaultMaxErrors = 10
+
 func (opt *Options) sanitize() *message.SyntaxError {
 	if opt == nil {
 		return message.NewLocaleError("", "", 0, locale.ErrRequired)
@@ -91,6 +100,10 @@ func (opt *Options) sanitize() *message.SyntaxError {
 	}
 	opt.paths = paths
 
+	if opt.MaxErrors == 0 {
+		opt.MaxErrors = defaultMaxErrors
+	}
+
 	// 生成 encoding
 	if opt.Encoding != "" {
 		opt.encoding, err = ianaindex.IANA.Encoding(opt.Encoding)
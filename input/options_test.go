@@ -8,10 +8,19 @@ import (
 
 	"github.com/issue9/assert"
 	"golang.org/x/text/encoding/simplifiedchinese"
+	"gopkg.in/yaml.v2"
 
 	"github.com/caixw/apidoc/v6/internal/lang"
 )
 
+func TestOptions_yaml(t *testing.T) {
+	a := assert.New(t)
+
+	o := &Options{}
+	a.NotError(yaml.Unmarshal([]byte(`max-errors: -1`), o))
+	a.Equal(o.MaxErrors, -1)
+}
+
 func TestOptions_Sanitize(t *testing.T) {
 	a := assert.New(t)
 
@@ -34,7 +43,8 @@ func TestOptions_Sanitize(t *testing.T) {
 	language := lang.Get("go")
 	o.Lang = "go"
 	a.NotError(o.sanitize())
-	a.Equal(o.Exts, language.Exts)
+	a.Equal(o.Exts, language.Exts).
+		Equal(o.MaxErrors, defaultMaxErrors)
 
 	// 指定了 Exts，自动调整扩展名样式。
 	o.Lang = "go"
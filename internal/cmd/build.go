@@ -4,26 +4,40 @@ package cmd
 
 import (
 	"flag"
+	"fmt"
 	"io"
 	"time"
 
 	"github.com/caixw/apidoc/v6"
 	"github.com/caixw/apidoc/v6/internal/locale"
-	"github.com/caixw/apidoc/v6/message"
 )
 
 var buildFlagSet *flag.FlagSet
 
+var buildStrict bool
+
 func initBuild() {
-	buildFlagSet = command.New("build", build, buildUsage(locale.CmdBuildUsage))
+	buildFlagSet = command.New("build", build, buildCmdUsage)
+	buildFlagSet.BoolVar(&buildStrict, "strict", false, locale.Sprintf(locale.FlagStrictUsage))
+	addFormatFlag(buildFlagSet)
 }
 
 func build(w io.Writer) error {
-	h := message.NewHandler(newHandlerFunc())
-	defer h.Stop()
+	h, stop, err := newHandler()
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	if cfg := apidoc.LoadConfig(h, getPath(buildFlagSet)); cfg != nil {
+		cfg.Build(time.Now())
+	}
+	return checkMessages(h, buildStrict)
+}
 
-	apidoc.LoadConfig(h, getPath(buildFlagSet)).Build(time.Now())
-	return nil
+func buildCmdUsage(w io.Writer) error {
+	_, err := fmt.Fprintln(w, locale.Sprintf(locale.CmdBuildUsage, getFlagSetUsage(buildFlagSet)))
+	return err
 }
 
 // 人命令行尾部获取路径参数，或是在未指定的情况下，采用当前目录。
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/caixw/apidoc/v6"
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/message"
+)
+
+var changelogFlagSet *flag.FlagSet
+
+var (
+	changelogType   string
+	changelogOutput string
+)
+
+func initChangelog() {
+	changelogFlagSet = command.New("changelog", doChangelog, changelogUsage)
+	changelogFlagSet.StringVar(&changelogType, "t", apidoc.ChangelogMarkdown, locale.Sprintf(locale.FlagChangelogTypeUsage))
+	changelogFlagSet.StringVar(&changelogOutput, "o", "", locale.Sprintf(locale.FlagChangelogOutputUsage))
+	addFormatFlag(changelogFlagSet)
+}
+
+func doChangelog(w io.Writer) error {
+	if changelogFlagSet.NArg() != 2 {
+		return changelogUsage(w)
+	}
+
+	h, stop, err := newHandler()
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	buf, err := apidoc.Changelog(changelogFlagSet.Arg(0), changelogFlagSet.Arg(1), changelogType)
+	if err != nil {
+		h.Error(message.Erro, err)
+		return errFailed
+	}
+
+	if changelogOutput == "" {
+		_, err = w.Write(buf.Bytes())
+		return err
+	}
+
+	if err := ioutil.WriteFile(changelogOutput, buf.Bytes(), os.ModePerm); err != nil {
+		h.Error(message.Erro, err)
+		return errFailed
+	}
+	h.Message(message.Succ, locale.ChangelogWriteSuccess, changelogOutput)
+	return nil
+}
+
+func changelogUsage(w io.Writer) error {
+	_, err := fmt.Fprintln(w, locale.Sprintf(locale.CmdChangelogUsage, getFlagSetUsage(changelogFlagSet)))
+	return err
+}
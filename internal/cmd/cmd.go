@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -39,6 +40,12 @@ var (
 
 var command *cmdopt.CmdOpt
 
+// 子命令执行过程中输出了错误信息
+//
+// 错误信息已经通过 message.Handler 输出，
+// 返回此值仅用于通知 Exec 以非零的状态码退出。
+var errFailed = errors.New("failed")
+
 func init() {
 	command = cmdopt.New(os.Stdout, flag.ContinueOnError, usage, func(name string) string {
 		return locale.Sprintf(locale.CmdNotFound, name)
@@ -51,18 +58,37 @@ func init() {
 	initLang()
 	initLocale()
 	initTest()
+	initLint()
+	initDiff()
+	initChangelog()
+	initImport()
 	initVersion()
 	initMock()
 	initStatic()
 }
 
 // Exec 执行程序
+//
+// 如果子命令执行失败，会以非零的状态码退出。
 func Exec() {
 	if err := command.Exec(os.Args[1:]); err != nil {
+		if err == errFailed {
+			os.Exit(1)
+		}
 		panic(err)
 	}
 }
 
+// 根据 h 中的消息数量判断子命令是否执行失败
+//
+// 输出了错误信息即表示失败，strict 为 true 时，输出警告信息也被当作失败。
+func checkMessages(h *message.Handler, strict bool) error {
+	if h.Count(message.Erro) > 0 || (strict && h.Count(message.Warn) > 0) {
+		return errFailed
+	}
+	return nil
+}
+
 func usage(w io.Writer) error {
 	cmds := strings.Join(command.Commands(), ",")
 	msg := locale.Sprintf(locale.CmdUsage, vars.Name, cmds, vars.RepoURL, vars.OfficialURL)
@@ -43,6 +43,23 @@ func TestNewHandlerFunc(t *testing.T) {
 	a.Contains(succ.String(), "succ")
 }
 
+func TestCheckMessages(t *testing.T) {
+	a := assert.New(t)
+
+	h := message.NewHandler(func(*message.Message) {})
+	h.Message(message.Info, "info")
+	h.Message(message.Succ, "succ")
+	a.NotError(checkMessages(h, true))
+
+	h.Message(message.Warn, "warn")
+	a.NotError(checkMessages(h, false))
+	a.Equal(checkMessages(h, true), errFailed)
+
+	h.Message(message.Erro, "erro")
+	a.Equal(checkMessages(h, false), errFailed)
+	h.Stop()
+}
+
 func TestBuildUsage(t *testing.T) {
 	a := assert.New(t)
 	w := new(bytes.Buffer)
@@ -19,12 +19,16 @@ var detectRecursive bool
 func initDetect() {
 	detectFlagSet = command.New("detect", detect, detectUsage)
 	detectFlagSet.BoolVar(&detectRecursive, "r", true, locale.Sprintf(locale.FlagDetectRecursive))
+	addFormatFlag(detectFlagSet)
 }
 
 func detect(w io.Writer) error {
 	path := getPath(detectFlagSet)
-	h := message.NewHandler(newHandlerFunc())
-	defer h.Stop()
+	h, stop, err := newHandler()
+	if err != nil {
+		return err
+	}
+	defer stop()
 
 	if err := apidoc.Detect(path, detectRecursive); err != nil {
 		return err
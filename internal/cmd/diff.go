@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/caixw/apidoc/v6"
+	"github.com/caixw/apidoc/v6/internal/locale"
+)
+
+var diffFlagSet *flag.FlagSet
+
+var diffStrict bool
+
+func initDiff() {
+	diffFlagSet = command.New("diff", doDiff, diffUsage)
+	diffFlagSet.BoolVar(&diffStrict, "strict", false, locale.Sprintf(locale.FlagStrictUsage))
+	addFormatFlag(diffFlagSet)
+}
+
+func doDiff(w io.Writer) error {
+	if diffFlagSet.NArg() != 2 {
+		return diffUsage(w)
+	}
+
+	h, stop, err := newHandler()
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	apidoc.Diff(h, diffFlagSet.Arg(0), diffFlagSet.Arg(1))
+	return checkMessages(h, diffStrict)
+}
+
+func diffUsage(w io.Writer) error {
+	_, err := fmt.Fprintln(w, locale.Sprintf(locale.CmdDiffUsage, getFlagSetUsage(diffFlagSet)))
+	return err
+}
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/internal/vars"
+	"github.com/caixw/apidoc/v6/message"
+)
+
+// 消息的输出格式
+const (
+	formatText  = "text"  // 带颜色的文本，错误和警告输出到 stderr，其它输出到 stdout
+	formatJSON  = "json"  // 每条消息输出一个 JSON 对象，每个对象占一行
+	formatSARIF = "sarif" // 在结束时输出 SARIF 2.1.0 格式的日志，仅包含错误和警告
+)
+
+// json 和 sarif 格式的输出通道
+var formatOut io.Writer = os.Stdout
+
+// 所有子命令共用的 -f 参数
+var messageFormat string
+
+func addFormatFlag(fs *flag.FlagSet) {
+	fs.StringVar(&messageFormat, "f", formatText, locale.Sprintf(locale.FlagFormatUsage))
+}
+
+// 根据 -f 参数生成 message.Handler
+//
+// 返回的函数用于停止 message.Handler，并输出剩余的内容，
+// 需要在子命令结束时调用。
+func newHandler() (*message.Handler, func(), error) {
+	switch messageFormat {
+	case formatText, "":
+		h := message.NewHandler(newHandlerFunc())
+		return h, h.Stop, nil
+	case formatJSON:
+		h := message.NewHandler(newJSONHandlerFunc(formatOut))
+		return h, h.Stop, nil
+	case formatSARIF:
+		log := &sarifLog{}
+		h := message.NewHandler(log.handle)
+		return h, func() {
+			h.Stop()
+			if err := log.write(formatOut); err != nil {
+				panic(err)
+			}
+		}, nil
+	default:
+		return nil, nil, locale.Errorf(locale.ErrInvalidValue)
+	}
+}
+
+// 以 JSON 格式输出的消息
+type jsonMessage struct {
+	Type      string `json:"type"`
+	Code      string `json:"code,omitempty"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	EndLine   int    `json:"endLine,omitempty"`
+	EndColumn int    `json:"endColumn,omitempty"`
+	Field     string `json:"field,omitempty"`
+	Text      string `json:"text"`
+}
+
+func newJSONHandlerFunc(out io.Writer) message.HandlerFunc {
+	enc := json.NewEncoder(out)
+
+	return func(msg *message.Message) {
+		m := &jsonMessage{
+			Type: msg.Type.String(),
+			Text: msg.Message,
+		}
+
+		if serr := msg.Err; serr != nil {
+			m.Code = serr.Code
+			m.File = serr.File
+			m.Line = serr.Line
+			m.Column = serr.Column
+			m.EndLine = serr.EndLine
+			m.EndColumn = serr.EndColumn
+			m.Field = serr.Field
+			m.Text = serr.Message
+		}
+
+		if err := enc.Encode(m); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// SARIF 2.1.0 日志中用到的部分结构
+//
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type (
+	// 消息由 message.Handler 在同一个协程中依次处理，不需要加锁。
+	sarifLog struct {
+		results []*sarifResult
+	}
+
+	sarifResult struct {
+		RuleID    string           `json:"ruleId,omitempty"`
+		Level     string           `json:"level"`
+		Message   sarifMessage     `json:"message"`
+		Locations []*sarifLocation `json:"locations,omitempty"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region *sarifRegion `json:"region,omitempty"`
+		} `json:"physicalLocation"`
+	}
+
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+		EndLine     int `json:"endLine,omitempty"`
+		EndColumn   int `json:"endColumn,omitempty"`
+	}
+
+	sarifRule struct {
+		ID string `json:"id"`
+	}
+)
+
+func (log *sarifLog) handle(msg *message.Message) {
+	var level string
+	switch msg.Type {
+	case message.Erro:
+		level = "error"
+	case message.Warn:
+		level = "warning"
+	default: // 普通的提示信息不属于代码检测的结果
+		return
+	}
+
+	r := &sarifResult{
+		Level:   level,
+		Message: sarifMessage{Text: msg.Message},
+	}
+
+	if serr := msg.Err; serr != nil {
+		r.RuleID = serr.Code
+		r.Message.Text = serr.Message
+
+		if serr.File != "" {
+			loc := &sarifLocation{}
+			loc.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(serr.File)
+			if serr.Line > 0 {
+				loc.PhysicalLocation.Region = &sarifRegion{
+					StartLine:   serr.Line,
+					StartColumn: serr.Column,
+					EndLine:     serr.EndLine,
+					EndColumn:   serr.EndColumn,
+				}
+			}
+			r.Locations = []*sarifLocation{loc}
+		}
+	}
+
+	log.results = append(log.results, r)
+}
+
+func (log *sarifLog) write(w io.Writer) error {
+	codes := make(map[string]bool, len(log.results))
+	rules := make([]*sarifRule, 0, len(log.results))
+	for _, r := range log.results {
+		if r.RuleID != "" && !codes[r.RuleID] {
+			codes[r.RuleID] = true
+			rules = append(rules, &sarifRule{ID: r.RuleID})
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	results := log.results
+	if results == nil {
+		results = []*sarifResult{} // results 为必须字段，不能输出 null
+	}
+
+	type driver struct {
+		Name           string       `json:"name"`
+		Version        string       `json:"version"`
+		InformationURI string       `json:"informationUri"`
+		Rules          []*sarifRule `json:"rules,omitempty"`
+	}
+	type run struct {
+		Tool struct {
+			Driver driver `json:"driver"`
+		} `json:"tool"`
+		Results []*sarifResult `json:"results"`
+	}
+
+	r := run{Results: results}
+	r.Tool.Driver = driver{
+		Name:           vars.Name,
+		Version:        vars.Version(),
+		InformationURI: vars.OfficialURL,
+		Rules:          rules,
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]interface{}{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs":    []run{r},
+	})
+}
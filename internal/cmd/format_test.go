@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/message"
+)
+
+func TestNewHandler(t *testing.T) {
+	a := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	formatOut = buf
+
+	messageFormat = "invalid"
+	h, stop, err := newHandler()
+	a.Error(err).Nil(h).Nil(stop)
+
+	// json
+	messageFormat = formatJSON
+	h, stop, err = newHandler()
+	a.NotError(err).NotNil(h).NotNil(stop)
+	serr := message.NewLocaleError("file.go", "apidoc/@version", 5, locale.ErrRequired)
+	serr.Column = 3
+	h.Error(message.Erro, serr)
+	h.Message(message.Succ, locale.TestSuccess)
+	stop()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	a.Equal(2, len(lines))
+	m := &jsonMessage{}
+	a.NotError(json.Unmarshal([]byte(lines[0]), m))
+	a.Equal(m, &jsonMessage{
+		Type:   "ERRO",
+		Code:   "E-REQUIRED",
+		File:   "file.go",
+		Line:   5,
+		Column: 3,
+		Field:  "apidoc/@version",
+		Text:   serr.Message,
+	})
+	m = &jsonMessage{}
+	a.NotError(json.Unmarshal([]byte(lines[1]), m))
+	a.Equal(m.Type, "SUCC").Empty(m.Code).Equal(m.Text, locale.Sprintf(locale.TestSuccess))
+
+	// sarif
+	buf.Reset()
+	messageFormat = formatSARIF
+	h, stop, err = newHandler()
+	a.NotError(err).NotNil(h).NotNil(stop)
+	h.Error(message.Warn, serr)
+	h.Message(message.Succ, locale.TestSuccess) // 不会出现在 sarif 中
+	stop()
+
+	log := &struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string       `json:"name"`
+					Rules []*sarifRule `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []*sarifResult `json:"results"`
+		} `json:"runs"`
+	}{}
+	a.NotError(json.Unmarshal(buf.Bytes(), log))
+	a.Equal(log.Version, "2.1.0").Equal(1, len(log.Runs))
+	run := log.Runs[0]
+	a.Equal(run.Tool.Driver.Rules, []*sarifRule{{ID: "E-REQUIRED"}}).
+		Equal(1, len(run.Results))
+	result := run.Results[0]
+	a.Equal(result.Level, "warning").
+		Equal(result.RuleID, "E-REQUIRED").
+		Equal(1, len(result.Locations))
+	loc := result.Locations[0].PhysicalLocation
+	a.Equal(loc.ArtifactLocation.URI, "file.go").
+		Equal(loc.Region, &sarifRegion{StartLine: 5, StartColumn: 3})
+
+	// 没有任何结果
+	buf.Reset()
+	h, stop, err = newHandler()
+	a.NotError(err)
+	stop()
+	a.Contains(buf.String(), `"results": []`)
+
+	messageFormat = formatText
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/caixw/apidoc/v6"
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/message"
+)
+
+var importFlagSet *flag.FlagSet
+
+var (
+	importType   string
+	importOutput string
+)
+
+func initImport() {
+	importFlagSet = command.New("import", doImport, importUsage)
+	importFlagSet.StringVar(&importType, "t", apidoc.ImportDoc, locale.Sprintf(locale.FlagImportTypeUsage))
+	importFlagSet.StringVar(&importOutput, "o", "", locale.Sprintf(locale.FlagImportOutputUsage))
+	addFormatFlag(importFlagSet)
+}
+
+func doImport(w io.Writer) error {
+	if importFlagSet.NArg() != 1 {
+		return importUsage(w)
+	}
+
+	h, stop, err := newHandler()
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	buf, err := apidoc.Import(h, importFlagSet.Arg(0), importType)
+	if err != nil {
+		h.Error(message.Erro, err)
+		return errFailed
+	}
+
+	if importOutput == "" {
+		_, err = w.Write(buf.Bytes())
+		return err
+	}
+
+	if err := ioutil.WriteFile(importOutput, buf.Bytes(), os.ModePerm); err != nil {
+		h.Error(message.Erro, err)
+		return errFailed
+	}
+	h.Message(message.Succ, locale.ImportWriteSuccess, importOutput)
+	return nil
+}
+
+func importUsage(w io.Writer) error {
+	_, err := fmt.Fprintln(w, locale.Sprintf(locale.CmdImportUsage, getFlagSetUsage(importFlagSet)))
+	return err
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/caixw/apidoc/v6"
+	"github.com/caixw/apidoc/v6/internal/locale"
+)
+
+var lintFlagSet *flag.FlagSet
+
+var lintStrict bool
+
+func initLint() {
+	lintFlagSet = command.New("lint", doLint, lintUsage)
+	lintFlagSet.BoolVar(&lintStrict, "strict", false, locale.Sprintf(locale.FlagStrictUsage))
+	addFormatFlag(lintFlagSet)
+}
+
+func doLint(w io.Writer) error {
+	h, stop, err := newHandler()
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	if cfg := apidoc.LoadConfig(h, getPath(lintFlagSet)); cfg != nil {
+		cfg.Lint()
+	}
+	return checkMessages(h, lintStrict)
+}
+
+func lintUsage(w io.Writer) error {
+	_, err := fmt.Fprintln(w, locale.Sprintf(locale.CmdLintUsage, getFlagSetUsage(lintFlagSet)))
+	return err
+}
@@ -66,13 +66,17 @@ func initMock() {
 	mockFlagSet = command.New("mock", doMock, mockUsage)
 	mockFlagSet.StringVar(&mockPort, "p", ":8080", locale.Sprintf(locale.FlagMockPortUsage))
 	mockFlagSet.Var(mockServers, "s", locale.Sprintf(locale.FlagMockServersUsage))
+	addFormatFlag(mockFlagSet)
 }
 
 func doMock(io.Writer) error {
 	path := getPath(mockFlagSet)
 
-	h := message.NewHandler(newHandlerFunc())
-	defer h.Stop()
+	h, stop, err := newHandler()
+	if err != nil {
+		return err
+	}
+	defer stop()
 
 	handler, err := apidoc.MockFile(h, path, mockServers)
 	if err != nil {
@@ -30,6 +30,7 @@ func initStatic() {
 	staticFlagSet.StringVar(&staticContentType, "ct", "", locale.Sprintf(locale.FlagStaticContentTypeUsage))
 	staticFlagSet.StringVar(&staticURL, "url", "", locale.Sprintf(locale.FlagStaticURLUsage))
 	staticFlagSet.BoolVar(&staticStylesheet, "stylesheet", false, locale.Sprintf(locale.FlagStaticStylesheetUsage))
+	addFormatFlag(staticFlagSet)
 }
 
 func static(io.Writer) (err error) {
@@ -38,8 +39,11 @@ func static(io.Writer) (err error) {
 		path = getPath(staticFlagSet)
 	}
 
-	h := message.NewHandler(newHandlerFunc())
-	defer h.Stop()
+	h, stop, err := newHandler()
+	if err != nil {
+		return err
+	}
+	defer stop()
 
 	var handler http.Handler
 
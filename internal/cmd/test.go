@@ -4,23 +4,37 @@ package cmd
 
 import (
 	"flag"
+	"fmt"
 	"io"
 
 	"github.com/caixw/apidoc/v6"
 	"github.com/caixw/apidoc/v6/internal/locale"
-	"github.com/caixw/apidoc/v6/message"
 )
 
 var testFlagSet *flag.FlagSet
 
+var testStrict bool
+
 func initTest() {
-	testFlagSet = command.New("test", test, buildUsage(locale.CmdTestUsage))
+	testFlagSet = command.New("test", test, testUsage)
+	testFlagSet.BoolVar(&testStrict, "strict", false, locale.Sprintf(locale.FlagStrictUsage))
+	addFormatFlag(testFlagSet)
 }
 
 func test(w io.Writer) error {
-	h := message.NewHandler(newHandlerFunc())
-	defer h.Stop()
+	h, stop, err := newHandler()
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	if cfg := apidoc.LoadConfig(h, getPath(testFlagSet)); cfg != nil {
+		cfg.Test()
+	}
+	return checkMessages(h, testStrict)
+}
 
-	apidoc.LoadConfig(h, getPath(testFlagSet)).Test()
-	return nil
+func testUsage(w io.Writer) error {
+	_, err := fmt.Fprintln(w, locale.Sprintf(locale.CmdTestUsage, getFlagSetUsage(testFlagSet)))
+	return err
 }
@@ -44,6 +44,18 @@ func Path(p string) string {
 	return filepath.Join(xpath.CurrPath("../../docs"), p)
 }
 
+// Content 返回被打包文件 name 的内容
+//
+// name 为相对于打包根目录的地址，比如 v6/apidoc.css，文件不存在时返回 nil。
+func Content(name string) []byte {
+	for _, info := range data {
+		if info.Name == name {
+			return info.Content
+		}
+	}
+	return nil
+}
+
 // Handler 返回文件服务中间件
 func Handler(folder string, stylesheet bool) http.Handler {
 	if folder == "" {
@@ -9,6 +9,8 @@ import (
 
 	"github.com/issue9/assert"
 	"github.com/issue9/assert/rest"
+
+	"github.com/caixw/apidoc/v6/internal/vars"
 )
 
 func TestDir(t *testing.T) {
@@ -35,6 +37,14 @@ func TestPath(t *testing.T) {
 	a.Equal(p1, p2)
 }
 
+func TestContent(t *testing.T) {
+	a := assert.New(t)
+
+	a.NotEmpty(Content("icon.svg"))
+	a.NotEmpty(Content(vars.DocVersion() + "/apidoc.css"))
+	a.Nil(Content("not-exists"))
+}
+
 func TestEmbeddedHandler(t *testing.T) {
 	a := assert.New(t)
 
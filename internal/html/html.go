@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: MIT
+
+// Package html 将文档转换成静态的 HTML 页面
+package html
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html/template"
+	"strings"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/internal/docs"
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/internal/markdown"
+	"github.com/caixw/apidoc/v6/internal/render"
+	"github.com/caixw/apidoc/v6/internal/vars"
+)
+
+// 模板中的文本与本地化内容的对应关系
+var labels = map[string]string{
+	"toc":         locale.OutputTOC,
+	"expand":      locale.OutputExpand,
+	"server":      locale.OutputServers,
+	"tag":         locale.OutputTags,
+	"method":      locale.OutputMethod,
+	"request":     locale.OutputRequest,
+	"response":    locale.OutputResponse,
+	"callback":    locale.OutputCallback,
+	"path-param":  locale.OutputPathParams,
+	"query":       locale.OutputQueries,
+	"header":      locale.OutputHeaders,
+	"cookie":      locale.OutputCookies,
+	"body":        locale.OutputBody,
+	"example":     locale.OutputExample,
+	"param":       locale.OutputParam,
+	"type":        locale.OutputType,
+	"value":       locale.OutputValue,
+	"description": locale.OutputDescription,
+	"enum":        locale.OutputEnum,
+	"deprecated":  locale.OutputDeprecated,
+}
+
+var tpl = template.Must(template.New("apidoc").Funcs(template.FuncMap{
+	"label":    func(string, ...interface{}) string { return "" }, // 在 Marshal 中根据文档的语言重新指定。
+	"richtext": richtext,
+	"id":       apiID,
+	"rows":     paramRows,
+	"body":     bodyRows,
+	"join":     join,
+	"args":     newParams,
+
+	// 以下函数需要用到文档的内容，在 Marshal 中重新指定。
+	"mimetypes": func(*doc.Request) string { return "" },
+	"responses": func(*doc.API) []*doc.Request { return nil },
+}).Parse(pageTemplate))
+
+type page struct {
+	Doc       *doc.Doc
+	Lang      string
+	Icon      template.URL
+	Style     template.CSS
+	Script    template.JS
+	Methods   []doc.Method
+	Groups    []*group
+	Generator string
+}
+
+// 导航中的一个分组
+type group struct {
+	Title string
+	APIs  []*doc.API
+}
+
+// 参数列表
+type params struct {
+	Title string
+	Rows  []*row
+}
+
+func newParams(title string, rows []*row) *params {
+	return &params{Title: title, Rows: rows}
+}
+
+// 参数列表中的一行
+type row struct {
+	*doc.Param
+	Parent string // 上一级的名称，以 . 结尾。
+}
+
+// Marshal 将 d 转换成 HTML 页面
+//
+// 页面的样式和脚本来自打包的 apidoc.css 和 apidoc.js，图标也以 data URL 的形式内嵌，
+// 生成的页面与 apidoc+xml 在浏览器中经 XSL 转换之后的基本相同，但是不依赖任何外部资源。
+func Marshal(d *doc.Doc) ([]byte, error) {
+	langID := render.LangID(d)
+
+	t, err := tpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+	t.Funcs(template.FuncMap{
+		"label": func(name string, v ...interface{}) string {
+			return locale.Translate(langID, labels[name], v...)
+		},
+		"mimetypes": func(req *doc.Request) string {
+			if req.Mimetype != "" {
+				return req.Mimetype
+			}
+			return strings.Join(d.Mimetypes, ", ")
+		},
+		"responses": func(api *doc.API) []*doc.Request {
+			return append(append(make([]*doc.Request, 0, len(api.Responses)+len(d.Responses)), api.Responses...), d.Responses...)
+		},
+	})
+
+	p := &page{
+		Doc:       d,
+		Lang:      d.Lang,
+		Icon:      template.URL(d.Logo),
+		Style:     template.CSS(docs.Content(vars.DocVersion() + "/apidoc.css")),
+		Script:    template.JS(docs.Content(vars.DocVersion() + "/apidoc.js")),
+		Methods:   methods(d),
+		Groups:    groups(d, locale.Translate(langID, locale.OutputUntagged)),
+		Generator: locale.Translate(langID, locale.GeneratorBy, vars.Name),
+	}
+	if p.Icon == "" {
+		p.Icon = template.URL("data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(docs.Content("icon.svg")))
+	}
+
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, p); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// 按 d.Tags 的顺序对 API 进行分组，没有标签的 API 归类到最后的分组中。
+func groups(d *doc.Doc, untagged string) []*group {
+	groups := render.Groups(d)
+
+	gs := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		title := untagged
+		if g.Tag != nil {
+			title = g.Tag.Title
+		}
+		gs = append(gs, &group{Title: title, APIs: g.APIs})
+	}
+	return gs
+}
+
+// 按出现的顺序返回所有不重复的请求方法
+func methods(d *doc.Doc) []doc.Method {
+	methods := make([]doc.Method, 0, 5)
+LOOP:
+	for _, api := range d.Apis {
+		for _, m := range methods {
+			if m == api.Method {
+				continue LOOP
+			}
+		}
+		methods = append(methods, api.Method)
+	}
+	return methods
+}
+
+// 生成 API 的 ID，与 apidoc.xsl 中的规则相同，比如 GET-users-_id_
+func apiID(api *doc.API) string {
+	return string(api.Method) + strings.NewReplacer("{", "_", "}", "_", "/", "-").Replace(api.Path.Path)
+}
+
+func richtext(text doc.Richtext) template.HTML {
+	if text.Type == doc.RichtextTypeHTML {
+		return template.HTML(text.Text)
+	}
+	return template.HTML(markdown.HTML(text.Text))
+}
+
+// 将 params 展开成参数列表，子元素的名称以 . 与父元素相连。
+func paramRows(params []*doc.Param) []*row {
+	return appendRows(nil, "", params)
+}
+
+func appendRows(rows []*row, parent string, params []*doc.Param) []*row {
+	for _, p := range params {
+		rows = append(rows, &row{Param: p, Parent: parent})
+
+		prefix := parent
+		if p.Name != "" {
+			prefix += p.Name + "."
+		}
+		rows = appendRows(rows, prefix, p.Items)
+		for _, u := range []*doc.Union{p.OneOf, p.AnyOf} {
+			if u != nil {
+				for _, item := range u.Items {
+					rows = appendRows(rows, prefix, item.Items)
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// 请求或是返回的报文内容，不存在报文时返回 nil。
+func bodyRows(req *doc.Request) []*row {
+	if req.Type == doc.None {
+		return nil
+	}
+
+	root := &doc.Param{
+		Name:        req.Name,
+		Type:        req.Type,
+		Array:       req.Array,
+		Items:       req.Items,
+		OneOf:       req.OneOf,
+		AnyOf:       req.AnyOf,
+		Summary:     req.Summary,
+		Enums:       req.Enums,
+		Description: req.Description,
+		Deprecated:  req.Deprecated,
+	}
+	return paramRows([]*doc.Param{root})
+}
+
+// 以 , 结尾的列表，与 apidoc.js 中的 data-* 属性的格式相同。
+func join(items []string) string {
+	var b strings.Builder
+	for _, item := range items {
+		b.WriteString(item)
+		b.WriteByte(',')
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+
+package html
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/doc/doctest"
+)
+
+func TestMarshal(t *testing.T) {
+	a := assert.New(t)
+
+	data, err := Marshal(doctest.Get())
+	a.NotError(err).NotEmpty(data)
+	text := string(data)
+
+	a.True(strings.HasPrefix(text, "<!DOCTYPE html>")).
+		True(strings.Contains(text, "<title>test</title>")).
+		True(strings.Contains(text, "data:image/svg")).           // 内嵌的图标
+		True(strings.Contains(text, "registerFilter")).           // 内嵌的 apidoc.js
+		True(strings.Contains(text, "header .menu")).             // 内嵌的 apidoc.css
+		True(strings.Contains(text, `<li data-tag="t1" role`)).   // 标签导航
+		True(strings.Contains(text, `<details id="GET-users" `)). // API 的锚点
+		True(strings.Contains(text, `<a href="#POST-users" class="del"`)).
+		True(strings.Contains(text, `data-tag="t1,t2,"`)).
+		True(strings.Contains(text, "<p>desc</p>"))
+
+	d := doctest.Get()
+	d.Logo = "https://example.com/logo.svg"
+	d.Description = doc.Richtext{Type: doc.RichtextTypeMarkdown, Text: "**desc**"}
+	data, err = Marshal(d)
+	a.NotError(err)
+	text = string(data)
+	a.True(strings.Contains(text, `<img src="https://example.com/logo.svg" />`)).
+		True(strings.Contains(text, "<p><strong>desc</strong></p>"))
+
+	// markdown 中不安全的链接不会被输出
+	d.Description = doc.Richtext{Type: doc.RichtextTypeMarkdown, Text: "[desc](javascript:alert)"}
+	data, err = Marshal(d)
+	a.NotError(err)
+	text = string(data)
+	a.True(strings.Contains(text, "<p>desc</p>")).
+		False(strings.Contains(text, "javascript:alert"))
+
+	// markdown 中的 HTML 标签会被转义
+	d.Description = doc.Richtext{
+		Type: doc.RichtextTypeMarkdown,
+		Text: `<a href="javascript:alert(1)">x</a> <img src=x onerror=alert(1)> [y](javascript:alert(1))`,
+	}
+	data, err = Marshal(d)
+	a.NotError(err)
+	text = string(data)
+	a.False(strings.Contains(text, `<a href="javascript:alert(1)">`)).
+		False(strings.Contains(text, "<img src=x")).
+		True(strings.Contains(text, "&lt;img src=x onerror=alert(1)&gt; y</p>"))
+}
+
+func TestAPIID(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(apiID(&doc.API{Method: "GET", Path: &doc.Path{Path: "/users/{id}"}}), "GET-users-_id_")
+}
+
+func TestBodyRows(t *testing.T) {
+	a := assert.New(t)
+
+	a.Nil(bodyRows(&doc.Request{Type: doc.None}))
+
+	rows := bodyRows(&doc.Request{
+		Type:  doc.Object,
+		Array: true,
+		Items: []*doc.Param{
+			{Name: "id", Type: doc.Number},
+			{Name: "group", Type: doc.Object, Items: []*doc.Param{{Name: "name", Type: doc.String}}},
+		},
+	})
+	a.Equal(4, len(rows))
+	a.Equal(rows[0].Parent, "").Equal(rows[0].Name, "").True(rows[0].Array)
+	a.Equal(rows[1].Parent, "").Equal(rows[1].Name, "id")
+	a.Equal(rows[3].Parent, "group.").Equal(rows[3].Name, "name")
+}
+
+func TestJoin(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(join(nil), "")
+	a.Equal(join([]string{"t1", "t2"}), "t1,t2,")
+}
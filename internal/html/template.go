@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: MIT
+
+package html
+
+// 页面的模板
+//
+// 页面结构及 class 名称与 docs/v6/apidoc.xsl 生成的内容保持一致，
+// 这样才能直接使用 apidoc.css 和 apidoc.js。
+const pageTemplate = `<!DOCTYPE html>
+<html{{with .Lang}} lang="{{.}}"{{end}}>
+<head>
+<title>{{.Doc.Title}}</title>
+<meta charset="UTF-8" />
+<meta name="viewport" content="width=device-width,initial-scale=1,maximum-scale=1" />
+<meta name="generator" content="apidoc" />
+<link rel="icon" type="image/svg+xml" href="{{.Icon}}" />
+{{- with .Doc.License}}
+<link rel="license" href="{{.URL}}" />
+{{- end}}
+<style>
+{{.Style}}
+nav.toc { margin: var(--padding) 0; }
+nav.toc ul { list-style: none; }
+nav.toc>ul { display: flex; flex-flow: wrap; padding: 0; }
+nav.toc>ul>li { flex: 1 1 auto; min-width: var(--min-width); }
+</style>
+<script>
+{{.Script}}
+</script>
+</head>
+<body>
+<header>
+<div class="wrap">
+    <h1>
+        <img src="{{.Icon}}" />
+        {{.Doc.Title}}
+        {{- with .Doc.Version}}<span class="version">&#160;({{.}})</span>{{end}}
+    </h1>
+
+    <div class="menus">
+        <label class="menu expand-selector" role="checkbox">
+            <input type="checkbox" />{{label "expand"}}
+        </label>
+
+        {{- if .Doc.Servers}}
+        <div class="menu server-selector" role="menu" aria-haspopup="true">
+            {{label "server"}}<span aria-hidden="true">&#160;&#x25bc;</span>
+            <ul role="menu" aria-hidden="true">
+                {{- range .Doc.Servers}}
+                <li data-server="{{.Name}}" role="menuitemcheckbox">
+                    <label><input type="checkbox" checked="checked" />&#160;{{.Name}}</label>
+                </li>
+                {{- end}}
+            </ul>
+        </div>
+        {{- end}}
+
+        {{- if .Doc.Tags}}
+        <div class="menu tag-selector" role="menu" aria-haspopup="true">
+            {{label "tag"}}<span aria-hidden="true">&#160;&#x25bc;</span>
+            <ul role="menu" aria-hidden="true">
+                {{- range .Doc.Tags}}
+                <li data-tag="{{.Name}}" role="menuitemcheckbox">
+                    <label><input type="checkbox" checked="checked" />&#160;{{.Title}}</label>
+                </li>
+                {{- end}}
+            </ul>
+        </div>
+        {{- end}}
+
+        <div class="menu method-selector" role="menu" aria-haspopup="true">
+            {{label "method"}}<span aria-hidden="true">&#160;&#x25bc;</span>
+            <ul role="menu" aria-hidden="true">
+                {{- range .Methods}}
+                <li data-method="{{.}}" role="menuitemcheckbox">
+                    <label><input type="checkbox" checked="checked" />&#160;{{.}}</label>
+                </li>
+                {{- end}}
+            </ul>
+        </div>
+
+        {{- /* 页面内容只有一种语言，但 apidoc.js 要求存在该元素 */}}
+        <div class="menu languages-selector"></div>
+    </div>
+</div>
+</header>
+
+<main>
+    {{- with .Doc.Description.Text}}
+    <div class="content">{{richtext $.Doc.Description}}</div>
+    {{- end}}
+
+    {{- if .Doc.Servers}}
+    <div class="servers">
+        {{- range .Doc.Servers}}
+        <div class="server">
+            <h4{{template "deprecated" .Deprecated}}>{{.Name}}</h4>
+            <p>{{.URL}}</p>
+            <div>{{if .Description.Text}}{{richtext .Description}}{{else}}{{.Summary}}{{end}}</div>
+        </div>
+        {{- end}}
+    </div>
+    {{- end}}
+
+    {{- if .Groups}}
+    <nav class="toc">
+        <h2>{{label "toc"}}</h2>
+        <ul>
+            {{- range .Groups}}
+            <li>
+                <h4>{{.Title}}</h4>
+                <ul>
+                    {{- range .APIs}}
+                    <li><a href="#{{id .}}"{{template "deprecated" .Deprecated}}>{{.Method}} {{.Path.Path}}</a> {{.Summary}}</li>
+                    {{- end}}
+                </ul>
+            </li>
+            {{- end}}
+        </ul>
+    </nav>
+    {{- end}}
+
+    {{- range .Doc.Apis}}
+    {{template "api" .}}
+    {{- end}}
+</main>
+
+<footer>
+<div class="wrap">
+    {{- with .Doc.License}}
+    <a href="{{.URL}}">{{.Text}}</a>
+    {{- end}}
+    <span>{{.Generator}}{{with .Doc.Created}} <time>{{.}}</time>{{end}}</span>
+</div>
+</footer>
+</body>
+</html>
+
+{{- define "api"}}
+<details id="{{id .}}" class="api" data-method="{{.Method}}," data-tag="{{join .Tags}}" data-server="{{join .Servers}}">
+    <summary>
+        <a class="link" href="#{{id .}}">&#128279;</a>
+        <span class="action">{{.Method}}</span>
+        <span{{template "deprecated" .Deprecated}}>{{.Path.Path}}</span>
+        <span class="summary">{{.Summary}}</span>
+    </summary>
+
+    {{- if .Description.Text}}
+    <div class="description">{{richtext .Description}}</div>
+    {{- end}}
+
+    <div class="body">
+        <div class="requests">
+            <h4 class="header">{{label "request"}}</h4>
+            {{- template "param" (args (label "path-param") (rows .Path.Params))}}
+            {{- template "param" (args (label "query") (rows .Path.Queries))}}
+            {{- template "param" (args (label "header") (rows .Headers))}}
+            {{- template "param" (args (label "cookie") (rows .Cookies))}}
+            {{- range .Requests}}
+            <details>
+                <summary>{{mimetypes .}}</summary>
+                {{- template "request" .}}
+            </details>
+            {{- end}}
+        </div>
+
+        <div class="responses">
+            <h4 class="header">{{label "response"}}</h4>
+            {{- range responses .}}
+            <details>
+                <summary>{{.Status}} {{mimetypes .}}</summary>
+                {{- template "request" .}}
+            </details>
+            {{- end}}
+        </div>
+    </div>
+
+    {{- with .Callback}}
+    <div class="callback" data-method="{{.Method}},">
+        <h3{{template "deprecated" .Deprecated}}>
+            {{label "callback"}}
+            <span class="summary">{{.Summary}}</span>
+        </h3>
+
+        {{- if .Description.Text}}
+        <div class="description">{{richtext .Description}}</div>
+        {{- end}}
+
+        <div class="body">
+            <div class="requests">
+                <h4 class="header">{{label "request"}}</h4>
+                {{- with .Path}}
+                {{- template "param" (args (label "path-param") (rows .Params))}}
+                {{- template "param" (args (label "query") (rows .Queries))}}
+                {{- end}}
+                {{- template "param" (args (label "header") (rows .Headers))}}
+                {{- template "param" (args (label "cookie") (rows .Cookies))}}
+                {{- range .Requests}}
+                <details>
+                    <summary>{{mimetypes .}}</summary>
+                    {{- template "request" .}}
+                </details>
+                {{- end}}
+            </div>
+
+            {{- if .Responses}}
+            <div class="responses">
+                <h4 class="header">{{label "response"}}</h4>
+                {{- range .Responses}}
+                <details>
+                    <summary>{{.Status}} {{mimetypes .}}</summary>
+                    {{- template "request" .}}
+                </details>
+                {{- end}}
+            </div>
+            {{- end}}
+        </div>
+    </div>
+    {{- end}}
+</details>
+{{- end}}
+
+{{- define "request"}}
+{{- template "param" (args (label "header") (rows .Headers))}}
+{{- template "param" (args (label "cookie") (rows .Cookies))}}
+{{- template "param" (args (label "body") (body .))}}
+{{- if .Examples}}
+<h4 class="title">&#x27a4;&#160;{{label "example"}}</h4>
+{{- range .Examples}}
+<pre class="example" title="{{.Mimetype}}">{{.Content}}</pre>
+{{- end}}
+{{- end}}
+{{- end}}
+
+{{- define "param"}}
+{{- if .Rows}}
+<div class="param">
+    <h4 class="title">&#x27a4;&#160;{{.Title}}</h4>
+    <table class="param-list">
+        <thead>
+            <tr>
+                <th>{{label "param"}}</th>
+                <th>{{label "type"}}</th>
+                <th>{{label "value"}}</th>
+                <th>{{label "description"}}</th>
+            </tr>
+        </thead>
+        <tbody>
+            {{- range .Rows}}
+            <tr{{template "deprecated" .Deprecated}}>
+                <th><span class="parent-type">{{.Parent}}</span>{{.Name}}</th>
+                <td>{{.Type}}{{if .Array}}[]{{end}}</td>
+                <td>{{if .Optional}}O{{else}}R{{end}} {{.Default}}</td>
+                <td>
+                    {{- if .Description.Text}}{{richtext .Description}}{{else}}{{.Summary}}{{end}}
+                    {{- if .Enums}}
+                    <p>{{label "enum"}}</p>
+                    <ul>
+                        {{- range .Enums}}
+                        <li{{template "deprecated" .Deprecated}}>{{.Value}}: {{if .Description.Text}}{{richtext .Description}}{{else}}{{.Summary}}{{end}}</li>
+                        {{- end}}
+                    </ul>
+                    {{- end}}
+                </td>
+            </tr>
+            {{- end}}
+        </tbody>
+    </table>
+</div>
+{{- end}}
+{{- end}}
+
+{{- define "deprecated"}}{{if .}} class="del" title="{{label "deprecated" .}}"{{end}}{{end}}
+`
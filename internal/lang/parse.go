@@ -3,21 +3,23 @@
 package lang
 
 import (
+	"bytes"
 	"math"
 	"unicode"
 
+	"github.com/caixw/apidoc/v6/doc"
 	"github.com/caixw/apidoc/v6/internal/locale"
 	"github.com/caixw/apidoc/v6/message"
 )
 
 var minsize = len("<api />")
 
-// Parse 分析 data 中的内容，并以行号作为键名，代码块作为键值返回
-func Parse(file string, data []byte, blocks []Blocker, h *message.Handler) map[int][]byte {
+// Parse 分析 data 中的内容，返回其中所有的文档块
+func Parse(file string, data []byte, blocks []Blocker, h *message.Handler) []*doc.Block {
 	l := &lexer{data: data, blocks: blocks}
 	var block Blocker
 
-	ret := map[int][]byte{}
+	ret := make([]*doc.Block, 0, 10)
 
 	for {
 		if l.atEOF() {
@@ -31,6 +33,7 @@ func Parse(file string, data []byte, blocks []Blocker, h *message.Handler) map[i
 			}
 		}
 
+		pos := l.pos
 		ln := l.lineNumber() + 1 // 记录当前的行号，1 表示从 1 开始记数
 		lines, ok := block.EndFunc(l)
 		if !ok { // 没有找到结束标签，那肯定是到文件尾了，可以直接返回。
@@ -40,31 +43,70 @@ func Parse(file string, data []byte, blocks []Blocker, h *message.Handler) map[i
 
 		block = nil // 重置 block
 
-		data := mergeLines(lines)
+		data, first, cols := mergeLines(lines, columns(l.data, pos, lines))
 		if len(data) > minsize {
-			ret[ln] = data
+			ret = append(ret, &doc.Block{
+				File:    file,
+				Line:    ln + first,
+				Data:    data,
+				Columns: cols,
+			})
 		}
 	} // end for
 }
 
+// 计算 lines 中每一行在源码中的起始列
+//
+// lines 为从 pos 所在行开始的连续多行，每一行都是源码中对应行的一部分，
+// 其中最后一行可能不包含结束符之后的内容。
+func columns(data []byte, pos int, lines [][]byte) []int {
+	cols := make([]int, 0, len(lines))
+	start := bytes.LastIndexByte(data[:pos], '\n') + 1
+
+	for _, line := range lines {
+		end := len(data)
+		if index := bytes.IndexByte(data[start:], '\n'); index >= 0 {
+			end = start + index + 1
+		}
+		src := data[start:end]
+
+		col := len(src) - len(line)
+		if !bytes.HasSuffix(src, line) {
+			if col = bytes.Index(src, line); col < 0 {
+				col = 0
+			}
+		}
+		cols = append(cols, col)
+
+		start = end
+	}
+
+	return cols
+}
+
 // 合并多行为一个 []byte 结构，并去掉前导空格
-func mergeLines(lines [][]byte) []byte {
-	lines = trimSpaceLine(lines)
+//
+// cols 表示 lines 中每一行在源码中的起始列。
+// 返回合并后的内容、内容的第一行在 lines 中的索引以及合并后每一行在源码中的起始列。
+func mergeLines(lines [][]byte, cols []int) ([]byte, int, []int) {
+	lines, first := trimSpaceLine(lines)
 
 	if len(lines) == 0 {
-		return nil
+		return nil, 0, nil
 	}
+	cols = append(make([]int, 0, len(lines)), cols[first:first+len(lines)]...)
 
 	// 去掉第一行的所有空格
 	for index, b := range lines[0] {
 		if !unicode.IsSpace(rune(b)) {
 			lines[0] = lines[0][index:]
+			cols[0] += index
 			break
 		}
 	}
 
 	if len(lines) == 1 {
-		return lines[0]
+		return lines[0], first, cols
 	}
 
 	min := math.MaxInt32
@@ -88,15 +130,16 @@ func mergeLines(lines [][]byte) []byte {
 
 	ret := make([]byte, 0, size+len(lines[0]))
 	ret = append(ret, lines[0]...)
-	for _, line := range lines[1:] {
+	for index, line := range lines[1:] {
 		if isSpaceLine(line) {
 			ret = append(ret, line...)
 		} else {
 			ret = append(ret, line[min:]...)
+			cols[index+1] += min
 		}
 	}
 
-	return ret
+	return ret, first, cols
 }
 
 // 是否为空白行
@@ -110,12 +153,15 @@ func isSpaceLine(line []byte) bool {
 	return true
 }
 
-// 去掉首尾的空行
-func trimSpaceLine(lines [][]byte) [][]byte {
+// 去掉首尾的空行，同时返回第一个非空行在原始内容中的索引
+func trimSpaceLine(lines [][]byte) ([][]byte, int) {
+	var first int
+
 	// 去掉开头空行
 	for index, line := range lines {
 		if !isSpaceLine(line) {
 			lines = lines[index:]
+			first = index
 			break
 		}
 	}
@@ -128,5 +174,5 @@ func trimSpaceLine(lines [][]byte) [][]byte {
 		}
 	}
 
-	return lines
+	return lines, first
 }
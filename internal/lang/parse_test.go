@@ -46,7 +46,9 @@ func TestParse(t *testing.T) {
 	ret = Parse("", []byte(code1), cStyle, h)
 	a.NotNil(ret).
 		Equal(1, len(ret)). // 字符串直接被过滤，不再返回
-		True(strings.Contains(string(ret[4]), "注释代码"))
+		True(strings.Contains(string(ret[0].Data), "注释代码")).
+		Equal(ret[0].Line, 5). // 第一行为空行，被去掉
+		Equal(ret[0].Columns, []int{3})
 	h.Stop()
 	a.Empty(erro.String())
 
@@ -68,9 +70,12 @@ func TestMergeLines(t *testing.T) {
 		[]byte("  l2\n"),
 		[]byte("   l3"),
 	}
-	a.Equal(string(mergeLines(lines)), `l1
+	data, first, cols := mergeLines(lines, []int{2, 2, 2})
+	a.Equal(string(data), `l1
 l2
- l3`)
+ l3`).
+		Equal(first, 0).
+		Equal(cols, []int{5, 4, 4})
 
 	// 包含空格行
 	lines = [][]byte{
@@ -79,20 +84,43 @@ l2
 		[]byte("  l2\n"),
 		[]byte("   l3"),
 	}
-	a.Equal(string(mergeLines(lines)), `l1
+	data, first, cols = mergeLines(lines, []int{0, 0, 0, 0})
+	a.Equal(string(data), `l1
     
 l2
- l3`)
+ l3`).
+		Equal(first, 0).
+		Equal(cols, []int{3, 0, 2, 2})
 
 	// 包含空行
 	lines = [][]byte{
+		[]byte("\n"),
 		[]byte("   l1\n"),
 		[]byte("\n"),
 		[]byte("  l2\n"),
 		[]byte("   l3"),
+		[]byte("\n"),
 	}
-	a.Equal(string(mergeLines(lines)), `l1
+	data, first, cols = mergeLines(lines, []int{1, 1, 1, 1, 1, 1})
+	a.Equal(string(data), `l1
 
 l2
- l3`)
+ l3`).
+		Equal(first, 1).
+		Equal(cols, []int{4, 1, 3, 3})
+}
+
+func TestColumns(t *testing.T) {
+	a := assert.New(t)
+
+	data := []byte(`code
+/* <api>
+ * l2
+ *   l3 */`)
+	lines := [][]byte{
+		data[7:14],
+		[]byte("l2\n"),
+		[]byte("  l3 "),
+	}
+	a.Equal(columns(data, 7, lines), []int{2, 3, 3})
 }
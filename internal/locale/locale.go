@@ -4,8 +4,6 @@
 package locale
 
 import (
-	"errors"
-
 	"golang.org/x/text/language"
 	"golang.org/x/text/language/display"
 	"golang.org/x/text/message"
@@ -58,9 +56,36 @@ func Sprintf(key message.Reference, v ...interface{}) string {
 	return localePrinter.Sprintf(key, v...)
 }
 
+// Error 本地化的错误信息
+//
+// 保留了生成错误信息的 key，可以通过 ErrorCode 获取其错误代码。
+type Error struct {
+	Key     message.Reference
+	Message string
+}
+
+func (err *Error) Error() string {
+	return err.Message
+}
+
 // Errorf 类似 fmt.Errorf，与特定的本地化绑定。
+//
+// 返回的错误类型为 *Error。
 func Errorf(key message.Reference, v ...interface{}) error {
-	return errors.New(Sprintf(key, v...))
+	return &Error{
+		Key:     key,
+		Message: Sprintf(key, v...),
+	}
+}
+
+// ErrorCode 返回 key 对应的错误代码
+//
+// 如果 key 不是错误信息，则返回空值。
+func ErrorCode(key message.Reference) string {
+	if k, ok := key.(string); ok {
+		return errorCodes[k]
+	}
+	return ""
 }
 
 // Translate 功能与 Sprintf 类似，但是可以指定本地化 ID 值。
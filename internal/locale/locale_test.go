@@ -27,6 +27,31 @@ func TestTranslate(t *testing.T) {
 	})
 }
 
+func TestErrorf(t *testing.T) {
+	a := assert.New(t)
+
+	err := Errorf(ErrRequired)
+	lerr, ok := err.(*Error)
+	a.True(ok).
+		Equal(lerr.Key, ErrRequired).
+		Equal(err.Error(), Sprintf(ErrRequired))
+}
+
+func TestErrorCode(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(ErrorCode(ErrRequired), "E-REQUIRED").
+		Empty(ErrorCode(ErrMessage)).
+		Empty(ErrorCode(1))
+
+	// 错误代码不能重复
+	codes := make(map[string]bool, len(errorCodes))
+	for _, code := range errorCodes {
+		a.False(codes[code], "重复的错误代码 %s", code)
+		codes[code] = true
+	}
+}
+
 func TestInit(t *testing.T) {
 	a := assert.New(t)
 
@@ -29,7 +29,15 @@ options 可以是以下参数：
 %s
 
 path 表示目录的路径，或不指定，表示使用当前工作目录 ./ 代替。`,
-	CmdTestUsage: "测试语法的正确性",
+	CmdTestUsage: `测试语法的正确性
+
+用法：
+apidoc test [options] [path]
+
+options 可以是以下参数：
+%s
+
+path 表示配置文件所在的目录，或不指定，表示使用当前工作目录 ./ 代替。`,
 	CmdMockUsage: `启用 mock 服务
 
 用法：
@@ -39,7 +47,54 @@ options 可以是以下参数：
 %s
 
 path 表示文档路径，或不指定，则使用当前工作目录 ./ 代替。`,
-	CmdBuildUsage: "生成文档内容",
+	CmdBuildUsage: `生成文档内容
+
+用法：
+apidoc build [options] [path]
+
+options 可以是以下参数：
+%s
+
+path 表示配置文件所在的目录，或不指定，表示使用当前工作目录 ./ 代替。`,
+	CmdLintUsage: `检测文档的风格
+
+用法：
+apidoc lint [options] [path]
+
+options 可以是以下参数：
+%s
+
+path 表示配置文件所在的目录，或不指定，表示使用当前工作目录 ./ 代替。
+检测的规则可以通过配置文件中的 lint 项进行调整。`,
+	CmdDiffUsage: `比较两个文档之间的差别
+
+用法：
+apidoc diff [options] old new
+
+options 可以是以下参数：
+%s
+
+old 和 new 分别表示旧文档和新文档的路径，可以是本地路径，也可以是 URL。
+包含破坏性的改动时，会以非零的状态码退出。`,
+	CmdChangelogUsage: `根据两个文档之间的差别生成更新日志
+
+用法：
+apidoc changelog [options] old new
+
+options 可以是以下参数：
+%s
+
+old 和 new 分别表示旧文档和新文档的路径，可以是本地路径，也可以是 URL。`,
+	CmdImportUsage: `根据 OpenAPI 3 文档生成 apidoc 文档
+
+用法：
+apidoc import [options] path
+
+options 可以是以下参数：
+%s
+
+path 表示 OpenAPI 文档的路径，可以是本地路径，也可以是 URL，支持 JSON 和 YAML 格式。
+无法转换的内容会以警告的形式输出。`,
 	CmdStaticUsage: `启用静态文件服务
 
 用法：
@@ -59,11 +114,18 @@ path 表示需要展示的文档路径，为空表示没有需要展示的文档
 	FlagStaticStylesheetUsage:  "指定 static 是否只启用样式文件内容",
 	FlagStaticContentTypeUsage: "指定 static 的 content-type 值，不指定，则根据扩展名自动获取",
 	FlagStaticURLUsage:         "指定 static 服务中文档的输出地址",
+	FlagFormatUsage:            "指定输出消息的格式，可以是 text、json 或是 sarif",
+	FlagStrictUsage:            "严格模式，输出警告信息时也返回非零的状态码",
+	FlagChangelogTypeUsage:     "指定更新日志的格式，可以是 markdown 或是 richtext",
+	FlagChangelogOutputUsage:   "指定更新日志的保存路径，不指定则输出到终端",
+	FlagImportTypeUsage:        "指定导入的格式，可以是 doc 或是 api",
+	FlagImportOutputUsage:      "指定导入内容的保存路径，不指定则输出到终端",
 
 	VersionInCompatible: "当前程序与配置文件中指定的版本号不兼容",
 	Complete:            "完成！文档保存在：%s，总用时：%v",
 	ConfigWriteSuccess:  "配置内容成功写入 %s",
 	TestSuccess:         "语法没有问题！",
+	LintSuccess:         "没有发现问题！",
 	LangID:              "ID",
 	LangName:            "名称",
 	LangExts:            "扩展名",
@@ -86,6 +148,70 @@ path 表示需要展示的文档路径，为空表示没有需要展示的文档
 	ErrDuplicateValue:        "重复的值",
 	ErrMessage:               "%s 位于 %s",
 	ErrNotFound:              "未找到该值",
+	ErrCircularReference:     "存在循环引用",
+	ErrDuplicateAPI:          "与位于 %s:%d 的 API 重复",
+	ErrDuplicateAPIID:        "与位于 %s:%d 的 API 有相同的 ID",
+	ErrRouteConflict:         "与位于 %s:%d 的 API 路由冲突",
+
+	// lint 规则的提示信息
+	LintNo4XXResponse:     "未声明 4XX 的返回内容",
+	LintNotKebabCase:      "%s 不是 kebab-case 格式",
+	LintEnumNoDescription: "枚举值 %s 缺少有效的描述内容",
+
+	// diff 子命令的提示信息
+	DiffMessage:        "%s：%s",
+	DiffAdded:          "新增",
+	DiffRemoved:        "删除",
+	DiffTypeChanged:    "类型由 %s 改为 %s",
+	DiffEnumAdded:      "添加了枚举值 %s",
+	DiffEnumRemoved:    "删除了枚举值 %s",
+	DiffBecameRequired: "由可选改为必须",
+	DiffBecameOptional: "由必须改为可选",
+	DiffDeprecated:     "将于 %s 被废弃",
+	DiffNoChanges:      "两个文档没有差别",
+	DiffSuggestVersion: "建议的版本号为 %s",
+	DiffVersionTooLow:  "新文档的版本号 %s 低于建议的版本号 %s",
+
+	// changelog 子命令的提示信息
+	ChangelogChanged:      "改动",
+	ChangelogDeprecated:   "废弃",
+	ChangelogBreaking:     "[破坏性]",
+	ChangelogUntagged:     "其它",
+	ChangelogVersion:      "（版本 %s）",
+	ChangelogSeparator:    "：",
+	ChangelogWriteSuccess: "更新日志成功写入 %s",
+
+	// import 子命令的提示信息
+	ImportUnsupported:  "无法转换为 apidoc 的内容，已忽略",
+	ImportWriteSuccess: "导入的内容成功写入 %s",
+
+	// 输出文档中的各类标题
+	OutputTOC:         "目录",
+	OutputUntagged:    "未分类",
+	OutputServers:     "服务",
+	OutputURL:         "地址",
+	OutputMethod:      "请求方法",
+	OutputPath:        "请求路径",
+	OutputTags:        "标签",
+	OutputVersion:     "版本",
+	OutputDeprecated:  "自 %s 起已废弃",
+	OutputPathParams:  "路径参数",
+	OutputQueries:     "查询参数",
+	OutputHeaders:     "报头",
+	OutputCookies:     "Cookie",
+	OutputRequest:     "请求",
+	OutputResponse:    "返回 %s",
+	OutputCallback:    "回调",
+	OutputParam:       "参数",
+	OutputType:        "类型",
+	OutputRequired:    "必填",
+	OutputDefault:     "默认值",
+	OutputDescription: "描述",
+	OutputExample:     "示例",
+	OutputExpand:      "展开",
+	OutputBody:        "报文",
+	OutputValue:       "值",
+	OutputEnum:        "枚举",
 
 	// logs
 	InfoPrefix:    "[信息] ",
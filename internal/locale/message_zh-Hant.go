@@ -29,7 +29,15 @@ options 可以是以下參數：
 %s
 
 path 表示目錄的路徑，或不指定，表示使用當前工作目錄 ./ 代替。`,
-	CmdTestUsage: "測試語法的正確性",
+	CmdTestUsage: `測試語法的正確性
+
+用法：
+apidoc test [options] [path]
+
+options 可以是以下參數：
+%s
+
+path 表示配置文件所在的目錄，或不指定，表示使用當前工作目錄 ./ 代替。`,
 	CmdMockUsage: `啟用 mock 服務
 
 用法：
@@ -39,7 +47,54 @@ options 可以是以下參數：
 %s
 
 path 表示文檔路徑，或不指定，則使用當前工作目錄 ./ 代替。`,
-	CmdBuildUsage: "生成文檔內容",
+	CmdBuildUsage: `生成文檔內容
+
+用法：
+apidoc build [options] [path]
+
+options 可以是以下參數：
+%s
+
+path 表示配置文件所在的目錄，或不指定，表示使用當前工作目錄 ./ 代替。`,
+	CmdLintUsage: `檢測文檔的風格
+
+用法：
+apidoc lint [options] [path]
+
+options 可以是以下參數：
+%s
+
+path 表示配置文件所在的目錄，或不指定，表示使用當前工作目錄 ./ 代替。
+檢測的規則可以通過配置文件中的 lint 項進行調整。`,
+	CmdDiffUsage: `比較兩個文檔之間的差別
+
+用法：
+apidoc diff [options] old new
+
+options 可以是以下參數：
+%s
+
+old 和 new 分別表示舊文檔和新文檔的路徑，可以是本地路徑，也可以是 URL。
+包含破壞性的改動時，會以非零的狀態碼退出。`,
+	CmdChangelogUsage: `根據兩個文檔之間的差別生成更新日誌
+
+用法：
+apidoc changelog [options] old new
+
+options 可以是以下參數：
+%s
+
+old 和 new 分別表示舊文檔和新文檔的路徑，可以是本地路徑，也可以是 URL。`,
+	CmdImportUsage: `根據 OpenAPI 3 文檔生成 apidoc 文檔
+
+用法：
+apidoc import [options] path
+
+options 可以是以下參數：
+%s
+
+path 表示 OpenAPI 文檔的路徑，可以是本地路徑，也可以是 URL，支持 JSON 和 YAML 格式。
+無法轉換的內容會以警告的形式輸出。`,
 	CmdStaticUsage: `啟用靜態文件服務
 
 用法：
@@ -59,11 +114,18 @@ path 表示需要展示的文檔路徑，為空表示沒有需要展示的文檔
 	FlagStaticStylesheetUsage:  "指定 static 是否只啟用樣式文件內容",
 	FlagStaticContentTypeUsage: "指定 static 的 content-type 值，不指定，則根據擴展名自動獲取",
 	FlagStaticURLUsage:         "指定 static 服務中文檔的輸出地址",
+	FlagFormatUsage:            "指定輸出消息的格式，可以是 text、json 或是 sarif",
+	FlagStrictUsage:            "嚴格模式，輸出警告信息時也返回非零的狀態碼",
+	FlagChangelogTypeUsage:     "指定更新日誌的格式，可以是 markdown 或是 richtext",
+	FlagChangelogOutputUsage:   "指定更新日誌的保存路徑，不指定則輸出到終端",
+	FlagImportTypeUsage:        "指定導入的格式，可以是 doc 或是 api",
+	FlagImportOutputUsage:      "指定導入內容的保存路徑，不指定則輸出到終端",
 
 	VersionInCompatible: "當前程序與配置文件中指定的版本號不兼容",
 	Complete:            "完成！文檔保存在：%s，總用時：%v",
 	ConfigWriteSuccess:  "配置內容成功寫入 %s",
 	TestSuccess:         "語法沒有問題！",
+	LintSuccess:         "沒有發現問題！",
 	LangID:              "ID",
 	LangName:            "名稱",
 	LangExts:            "擴展名",
@@ -86,6 +148,70 @@ path 表示需要展示的文檔路徑，為空表示沒有需要展示的文檔
 	ErrDuplicateValue:        "重復的值",
 	ErrMessage:               "%s 位於 %s",
 	ErrNotFound:              "未找到該值",
+	ErrCircularReference:     "存在循環引用",
+	ErrDuplicateAPI:          "與位於 %s:%d 的 API 重複",
+	ErrDuplicateAPIID:        "與位於 %s:%d 的 API 有相同的 ID",
+	ErrRouteConflict:         "與位於 %s:%d 的 API 路由沖突",
+
+	// lint 規則的提示信息
+	LintNo4XXResponse:     "未聲明 4XX 的返回內容",
+	LintNotKebabCase:      "%s 不是 kebab-case 格式",
+	LintEnumNoDescription: "枚舉值 %s 缺少有效的描述內容",
+
+	// diff 子命令的提示信息
+	DiffMessage:        "%s：%s",
+	DiffAdded:          "新增",
+	DiffRemoved:        "刪除",
+	DiffTypeChanged:    "類型由 %s 改為 %s",
+	DiffEnumAdded:      "添加了枚舉值 %s",
+	DiffEnumRemoved:    "刪除了枚舉值 %s",
+	DiffBecameRequired: "由可選改為必須",
+	DiffBecameOptional: "由必須改為可選",
+	DiffDeprecated:     "將於 %s 被廢棄",
+	DiffNoChanges:      "兩個文檔沒有差別",
+	DiffSuggestVersion: "建議的版本號為 %s",
+	DiffVersionTooLow:  "新文檔的版本號 %s 低於建議的版本號 %s",
+
+	// changelog 子命令的提示信息
+	ChangelogChanged:      "改動",
+	ChangelogDeprecated:   "廢棄",
+	ChangelogBreaking:     "[破壞性]",
+	ChangelogUntagged:     "其它",
+	ChangelogVersion:      "（版本 %s）",
+	ChangelogSeparator:    "：",
+	ChangelogWriteSuccess: "更新日誌成功寫入 %s",
+
+	// import 子命令的提示信息
+	ImportUnsupported:  "無法轉換為 apidoc 的內容，已忽略",
+	ImportWriteSuccess: "導入的內容成功寫入 %s",
+
+	// 輸出文檔中的各類標題
+	OutputTOC:         "目錄",
+	OutputUntagged:    "未分類",
+	OutputServers:     "服務",
+	OutputURL:         "地址",
+	OutputMethod:      "請求方法",
+	OutputPath:        "請求路徑",
+	OutputTags:        "標簽",
+	OutputVersion:     "版本",
+	OutputDeprecated:  "自 %s 起已廢棄",
+	OutputPathParams:  "路徑參數",
+	OutputQueries:     "查詢參數",
+	OutputHeaders:     "報頭",
+	OutputCookies:     "Cookie",
+	OutputRequest:     "請求",
+	OutputResponse:    "返回 %s",
+	OutputCallback:    "回調",
+	OutputParam:       "參數",
+	OutputType:        "類型",
+	OutputRequired:    "必填",
+	OutputDefault:     "默認值",
+	OutputDescription: "描述",
+	OutputExample:     "示例",
+	OutputExpand:      "展開",
+	OutputBody:        "報文",
+	OutputValue:       "值",
+	OutputEnum:        "枚舉",
 
 	// logs
 	InfoPrefix:    "[信息] ",
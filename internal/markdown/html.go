@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: MIT
+
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingRE     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	hrRE          = regexp.MustCompile(`^(\*\s*){3,}$|^(-\s*){3,}$|^(_\s*){3,}$`)
+	ulRE          = regexp.MustCompile(`^[-*+]\s+`)
+	olRE          = regexp.MustCompile(`^\d+[.)]\s+`)
+	codeSpanRE    = regexp.MustCompile("`+[^`]+`+")
+	imageRE       = regexp.MustCompile(`!\[([^\]]*)\]\(((?:[^()\s]|\([^()\s]*\))+)\)`)
+	linkRE        = regexp.MustCompile(`\[([^\]]+)\]\(((?:[^()\s]|\([^()\s]*\))+)\)`)
+	strongRE      = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	emRE          = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+	placeholderRE = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// HTML 将 Markdown 格式的 text 转换成 HTML
+//
+// 仅支持常用的语法：标题、段落、列表、引用、代码块和分隔线，
+// 以及行内的代码、链接、图片、粗体和斜体，HTML 标签会被转义输出。
+// 其它内容都作为普通的文本输出。
+func HTML(text string) string {
+	return blocks(strings.Split(dedent(text), "\n"))
+}
+
+func blocks(lines []string) string {
+	var b strings.Builder
+	var para []string
+
+	flush := func() {
+		if len(para) > 0 {
+			b.WriteString("<p>" + inline(strings.Join(para, "\n")) + "</p>\n")
+			para = para[:0]
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			flush()
+			fence := trimmed[:3]
+			lang := strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1]))
+			code := make([]string, 0, 10)
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code")
+			if lang != "" {
+				b.WriteString(` class="language-` + html.EscapeString(lang) + `"`)
+			}
+			b.WriteString(">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+		case headingRE.MatchString(trimmed):
+			flush()
+			m := headingRE.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(m[1]))
+			b.WriteString("<h" + level + ">" + inline(m[2]) + "</h" + level + ">\n")
+		case hrRE.MatchString(trimmed):
+			flush()
+			b.WriteString("<hr />\n")
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			quote := make([]string, 0, 10)
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				l := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quote = append(quote, strings.TrimPrefix(l, " "))
+			}
+			i--
+			b.WriteString("<blockquote>\n" + blocks(quote) + "</blockquote>\n")
+		case ulRE.MatchString(line) || olRE.MatchString(line):
+			flush()
+			var items string
+			items, i = list(lines, i)
+			b.WriteString(items)
+		default:
+			para = append(para, trimmed)
+		}
+	}
+	flush()
+
+	return b.String()
+}
+
+// 从 lines[start] 开始解析一个列表，返回列表的 HTML 以及列表最后一行的索引。
+//
+// 缩进的行属于上一个列表项，可以包含嵌套的列表。
+func list(lines []string, start int) (string, int) {
+	re, tag := ulRE, "ul"
+	if olRE.MatchString(lines[start]) {
+		re, tag = olRE, "ol"
+	}
+
+	var b strings.Builder
+	b.WriteString("<" + tag + ">\n")
+
+	i := start
+	for i < len(lines) && re.MatchString(lines[i]) {
+		item := []string{re.ReplaceAllString(lines[i], "")}
+		for i++; i < len(lines); i++ {
+			l := lines[i]
+			if strings.TrimSpace(l) == "" || (l[0] != ' ' && l[0] != '\t') {
+				break
+			}
+			item = append(item, l)
+		}
+
+		if len(item) == 1 {
+			b.WriteString("<li>" + inline(item[0]) + "</li>\n")
+		} else {
+			b.WriteString("<li>" + inline(item[0]) + "\n" + blocks(strings.Split(dedent(strings.Join(item[1:], "\n")), "\n")) + "</li>\n")
+		}
+	}
+
+	b.WriteString("</" + tag + ">\n")
+	return b.String(), i - 1
+}
+
+// 转换行内的元素
+//
+// 代码不需要转换，先以占位符代替，最后再还原。
+// 链接和图片的地址中可以包含一层括号，比如 https://example.com/a_(b)。
+func inline(text string) string {
+	codes := make([]string, 0, 5)
+	placeholder := func(s string) string {
+		codes = append(codes, s)
+		return "\x00" + strconv.Itoa(len(codes)-1) + "\x00"
+	}
+
+	text = codeSpanRE.ReplaceAllStringFunc(text, func(s string) string {
+		return placeholder("<code>" + html.EscapeString(strings.TrimSpace(strings.Trim(s, "`"))) + "</code>")
+	})
+
+	text = html.EscapeString(text)
+	text = imageRE.ReplaceAllStringFunc(text, func(s string) string {
+		m := imageRE.FindStringSubmatch(s)
+		if !isSafeURL(m[2]) {
+			return m[1]
+		}
+		return `<img src="` + m[2] + `" alt="` + m[1] + `" />`
+	})
+	text = linkRE.ReplaceAllStringFunc(text, func(s string) string {
+		m := linkRE.FindStringSubmatch(s)
+		if !isSafeURL(m[2]) {
+			return m[1]
+		}
+		return `<a href="` + m[2] + `">` + m[1] + `</a>`
+	})
+	text = strongRE.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = emRE.ReplaceAllString(text, "<em>$1$2</em>")
+
+	return placeholderRE.ReplaceAllStringFunc(text, func(s string) string {
+		index, _ := strconv.Atoi(strings.Trim(s, "\x00"))
+		return codes[index]
+	})
+}
+
+// 链接和图片的地址只能是 http、https、mailto 或是相对地址，
+// 以防止 javascript: 等地址被当作可执行的链接输出。
+//
+// u 为已经转义过的内容。
+func isSafeURL(u string) bool {
+	u = strings.ToLower(html.UnescapeString(u))
+
+	end := strings.IndexAny(u, "/?#")
+	if end < 0 {
+		end = len(u)
+	}
+	index := strings.IndexByte(u[:end], ':')
+	if index < 0 { // 相对地址
+		return true
+	}
+
+	switch u[:index] {
+	case "http", "https", "mailto":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: MIT
+
+package markdown
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+)
+
+func TestHTML(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(HTML(""), "")
+	a.Equal(HTML("# title\n\ntext1\ntext2\n\n---"), "<h1>title</h1>\n<p>text1\ntext2</p>\n<hr />\n")
+	a.Equal(HTML("```go\nif a < b {}\n```"), "<pre><code class=\"language-go\">if a &lt; b {}</code></pre>\n")
+	a.Equal(HTML("> quote\n> **bold**"), "<blockquote>\n<p>quote\n<strong>bold</strong></p>\n</blockquote>\n")
+	a.Equal(HTML("- item1\n- item2\n  1. sub1\n  2. sub2\n\ntext"),
+		"<ul>\n<li>item1</li>\n<li>item2\n<ol>\n<li>sub1</li>\n<li>sub2</li>\n</ol>\n</li>\n</ul>\n<p>text</p>\n")
+}
+
+func TestInline(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(inline("a < b & c"), "a &lt; b &amp; c")
+	a.Equal(inline("`<code>` *em* _em_ snake_case_name"), "<code>&lt;code&gt;</code> <em>em</em> <em>em</em> snake_case_name")
+	a.Equal(inline("[apidoc](https://apidoc.tools) ![logo](logo.svg)"), `<a href="https://apidoc.tools">apidoc</a> <img src="logo.svg" alt="logo" />`)
+	a.Equal(inline("<b>html</b>"), "&lt;b&gt;html&lt;/b&gt;")
+	a.Equal(inline(`<a href="javascript:alert(1)">x</a><img src=x onerror=alert(1)>`),
+		"&lt;a href=&#34;javascript:alert(1)&#34;&gt;x&lt;/a&gt;&lt;img src=x onerror=alert(1)&gt;")
+	a.Equal(inline("[wiki](https://example.com/a_(b)) ![x](x(1).png)"),
+		`<a href="https://example.com/a_(b)">wiki</a> <img src="x(1).png" alt="x" />`)
+
+	// 不安全的地址只输出文本
+	a.Equal(inline("[x](javascript:alert) ![y](JavaScript:alert)"), "x y")
+	a.Equal(inline("[x](javascript:alert(1)) ![y](javascript:alert(1))"), "x y")
+	a.Equal(inline("[x](data:text/html,xx) [mail](mailto:a@example.com)"), `x <a href="mailto:a@example.com">mail</a>`)
+	a.Equal(inline("[x](/path/a:b?q=c:d)"), `<a href="/path/a:b?q=c:d">x</a>`)
+}
+
+func TestIsSafeURL(t *testing.T) {
+	a := assert.New(t)
+
+	a.True(isSafeURL("https://example.com")).
+		True(isSafeURL("HTTP://example.com")).
+		True(isSafeURL("mailto:a@example.com")).
+		True(isSafeURL("logo.svg")).
+		True(isSafeURL("#id")).
+		True(isSafeURL("./a:b")).
+		True(isSafeURL("?a=b:c"))
+
+	a.False(isSafeURL("javascript:alert(1)")).
+		False(isSafeURL("JavaScript://%0aalert(1)")).
+		False(isSafeURL("vbscript:x")).
+		False(isSafeURL("data:text/html,x")).
+		False(isSafeURL("javascript&#58;alert(1)")).
+		False(isSafeURL("\x01javascript:alert(1)"))
+}
@@ -0,0 +1,484 @@
+// SPDX-License-Identifier: MIT
+
+// Package markdown 将文档转换成 Markdown 格式
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	xmessage "golang.org/x/text/message"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/internal/render"
+)
+
+type writer struct {
+	buf     *bytes.Buffer
+	langID  string
+	anchors map[*doc.API]string
+}
+
+// Marshal 将 d 转换成 Markdown 格式的内容
+//
+// API 按标签分组，同一个 API 有多个标签时，目录中会出现在每一个标签下，
+// 但是内容只出现在第一个标签中；没有标签的 API 统一归类到最后的分组中。
+func Marshal(d *doc.Doc) ([]byte, error) {
+	w := &writer{
+		buf:     new(bytes.Buffer),
+		langID:  render.LangID(d),
+		anchors: make(map[*doc.API]string, len(d.Apis)),
+	}
+
+	used := make(map[string]bool, len(d.Apis))
+	for _, api := range d.Apis {
+		anchor := apiAnchor(api)
+		for i := 2; used[anchor]; i++ {
+			anchor = apiAnchor(api) + "-" + strconv.Itoa(i)
+		}
+		used[anchor] = true
+		w.anchors[api] = anchor
+	}
+
+	groups := render.Groups(d)
+
+	w.printf("# %s", d.Title)
+	if d.Version != "" {
+		w.printf(" %s", d.Version)
+	}
+	w.printf("\n\n")
+	w.richtext(d.Description)
+
+	w.toc(groups)
+	w.servers(d.Servers)
+
+	rendered := make(map[*doc.API]bool, len(d.Apis))
+	for _, g := range groups {
+		w.printf("## <a name=\"%s\"></a>%s\n\n", groupAnchor(g), w.groupTitle(g))
+		if g.Tag != nil && g.Tag.Deprecated != "" {
+			w.deprecated(g.Tag.Deprecated)
+		}
+
+		for _, api := range g.APIs {
+			if !rendered[api] {
+				rendered[api] = true
+				w.api(api)
+			}
+		}
+	}
+
+	return w.buf.Bytes(), nil
+}
+
+// 分组的标题，未分类的分组采用本地化的名称。
+func (w *writer) groupTitle(g *render.Group) string {
+	if g.Tag == nil {
+		return w.sprintf(locale.OutputUntagged)
+	}
+	return g.Tag.Title
+}
+
+func groupAnchor(g *render.Group) string {
+	if g.Tag == nil {
+		return "untagged"
+	}
+	return "tag-" + g.Tag.Name
+}
+
+func (w *writer) printf(format string, v ...interface{}) {
+	fmt.Fprintf(w.buf, format, v...)
+}
+
+func (w *writer) sprintf(key xmessage.Reference, v ...interface{}) string {
+	return locale.Translate(w.langID, key, v...)
+}
+
+func (w *writer) toc(groups []*render.Group) {
+	if len(groups) == 0 {
+		return
+	}
+
+	w.printf("## %s\n\n", w.sprintf(locale.OutputTOC))
+	for _, g := range groups {
+		w.printf("- [%s](#%s)\n", w.groupTitle(g), groupAnchor(g))
+
+		for _, api := range g.APIs {
+			w.printf("  - [%s](#%s)", apiTitle(api), w.anchors[api])
+			if api.Summary != "" {
+				w.printf(" `%s %s`", api.Method, api.Path.Path)
+			}
+			w.printf("\n")
+		}
+	}
+	w.printf("\n")
+}
+
+func (w *writer) servers(servers []*doc.Server) {
+	if len(servers) == 0 {
+		return
+	}
+
+	w.printf("## %s\n\n", w.sprintf(locale.OutputServers))
+	w.tableHeader(locale.OutputServers, locale.OutputURL, locale.OutputDescription)
+	for _, srv := range servers {
+		desc := srv.Summary
+		if desc == "" {
+			desc = srv.Description.Text
+		}
+		if srv.Deprecated != "" {
+			desc = appendLine(desc, w.sprintf(locale.OutputDeprecated, srv.Deprecated))
+		}
+		w.tableRow(srv.Name, srv.URL, desc)
+	}
+	w.printf("\n")
+}
+
+func (w *writer) api(api *doc.API) {
+	w.printf("### <a name=\"%s\"></a>%s\n\n", w.anchors[api], apiTitle(api))
+	if api.Deprecated != "" {
+		w.deprecated(api.Deprecated)
+	}
+
+	w.tableHeader(locale.OutputMethod, locale.OutputPath, locale.OutputServers, locale.OutputTags, locale.OutputVersion)
+	w.tableRow(string(api.Method), code(api.Path.Path), strings.Join(api.Servers, ", "), strings.Join(api.Tags, ", "), string(api.Version))
+	w.printf("\n")
+
+	w.richtext(api.Description)
+
+	w.params(4, locale.OutputPathParams, api.Path.Params)
+	w.params(4, locale.OutputQueries, api.Path.Queries)
+	w.params(4, locale.OutputHeaders, api.Headers)
+	w.params(4, locale.OutputCookies, api.Cookies)
+
+	for _, req := range api.Requests {
+		w.request(4, w.sprintf(locale.OutputRequest), req)
+	}
+
+	for _, resp := range api.Responses {
+		w.request(4, w.sprintf(locale.OutputResponse, resp.Status), resp)
+	}
+
+	if cb := api.Callback; cb != nil {
+		w.callback(cb)
+	}
+}
+
+func (w *writer) callback(cb *doc.Callback) {
+	w.printf("#### %s\n\n", w.sprintf(locale.OutputCallback))
+	if cb.Deprecated != "" {
+		w.deprecated(cb.Deprecated)
+	}
+
+	var path string
+	if cb.Path != nil {
+		path = code(cb.Path.Path)
+	}
+	w.tableHeader(locale.OutputMethod, locale.OutputPath)
+	w.tableRow(string(cb.Method), path)
+	w.printf("\n")
+
+	if cb.Summary != "" {
+		w.printf("%s\n\n", cb.Summary)
+	}
+	w.richtext(cb.Description)
+
+	if cb.Path != nil {
+		w.params(5, locale.OutputPathParams, cb.Path.Params)
+		w.params(5, locale.OutputQueries, cb.Path.Queries)
+	}
+	w.params(5, locale.OutputHeaders, cb.Headers)
+	w.params(5, locale.OutputCookies, cb.Cookies)
+
+	for _, req := range cb.Requests {
+		w.request(5, w.sprintf(locale.OutputRequest), req)
+	}
+
+	for _, resp := range cb.Responses {
+		w.request(5, w.sprintf(locale.OutputResponse, resp.Status), resp)
+	}
+}
+
+// 输出请求或是返回的内容，level 为标题的级别。
+func (w *writer) request(level int, title string, req *doc.Request) {
+	w.printf("%s %s", strings.Repeat("#", level), title)
+	if req.Mimetype != "" {
+		w.printf(" `%s`", req.Mimetype)
+	}
+	w.printf("\n\n")
+
+	if req.Deprecated != "" {
+		w.deprecated(req.Deprecated)
+	}
+	if req.Summary != "" {
+		w.printf("%s\n\n", req.Summary)
+	}
+	w.richtext(req.Description)
+
+	var rows [][]string
+	if len(req.Items) == 0 && !req.IsUnion() {
+		if req.Type != doc.None {
+			rows = append(rows, []string{"", code(typeName(req.Type, req.Array)), "", "", w.enums("", req.Enums)})
+		}
+	} else {
+		rows = w.paramRows(rows, "", req.Items)
+		rows = w.unionRows(rows, "", req.OneOf)
+		rows = w.unionRows(rows, "", req.AnyOf)
+	}
+	w.table(level+1, locale.OutputParam, rows)
+
+	w.params(level+1, locale.OutputHeaders, req.Headers)
+	w.params(level+1, locale.OutputCookies, req.Cookies)
+
+	for _, exp := range req.Examples {
+		w.example(level+1, exp)
+	}
+}
+
+func (w *writer) example(level int, exp *doc.Example) {
+	w.printf("%s %s", strings.Repeat("#", level), w.sprintf(locale.OutputExample))
+	if exp.Mimetype != "" {
+		w.printf(" `%s`", exp.Mimetype)
+	}
+	w.printf("\n\n")
+
+	if exp.Summary != "" {
+		w.printf("%s\n\n", exp.Summary)
+	}
+	w.richtext(exp.Description)
+
+	content := dedent(exp.Content)
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	w.printf("%s%s\n%s\n%s\n\n", fence, codeLang(exp.Mimetype), content, fence)
+}
+
+// 以表格的形式输出 params，没有内容则不输出任何内容。
+func (w *writer) params(level int, key xmessage.Reference, params []*doc.Param) {
+	w.table(level, key, w.paramRows(nil, "", params))
+}
+
+func (w *writer) table(level int, key xmessage.Reference, rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+
+	w.printf("%s %s\n\n", strings.Repeat("#", level), w.sprintf(key))
+	w.tableHeader(locale.OutputParam, locale.OutputType, locale.OutputRequired, locale.OutputDefault, locale.OutputDescription)
+	for _, row := range rows {
+		w.tableRow(row...)
+	}
+	w.printf("\n")
+}
+
+// 将 params 转换成表格的行，子元素的名称以 . 与父元素相连，比如 user.name。
+func (w *writer) paramRows(rows [][]string, prefix string, params []*doc.Param) [][]string {
+	for _, p := range params {
+		name := prefix + p.Name
+
+		var required, def string
+		if !p.Optional && p.Default == "" {
+			required = "✓"
+		}
+		if p.Default != "" {
+			def = code(p.Default)
+		}
+
+		desc := p.Summary
+		if desc == "" {
+			desc = p.Description.Text
+		}
+		desc = w.enums(desc, p.Enums)
+		if p.Deprecated != "" {
+			desc = appendLine(desc, w.sprintf(locale.OutputDeprecated, p.Deprecated))
+		}
+
+		rows = append(rows, []string{code(name), code(typeName(p.Type, p.Array)), required, def, desc})
+		rows = w.paramRows(rows, name+".", p.Items)
+		rows = w.unionRows(rows, name+".", p.OneOf)
+		rows = w.unionRows(rows, name+".", p.AnyOf)
+	}
+	return rows
+}
+
+// 联合类型的各个子类型的字段，直接作为父元素的字段。
+func (w *writer) unionRows(rows [][]string, prefix string, u *doc.Union) [][]string {
+	if u == nil {
+		return rows
+	}
+
+	for _, item := range u.Items {
+		rows = w.paramRows(rows, prefix, item.Items)
+	}
+	return rows
+}
+
+func (w *writer) enums(desc string, enums []*doc.Enum) string {
+	for _, e := range enums {
+		item := code(e.Value)
+		if e.Summary != "" {
+			item += " " + e.Summary
+		} else if e.Description.Text != "" {
+			item += " " + e.Description.Text
+		}
+		if e.Deprecated != "" {
+			item += " (" + w.sprintf(locale.OutputDeprecated, e.Deprecated) + ")"
+		}
+		desc = appendLine(desc, item)
+	}
+	return desc
+}
+
+func (w *writer) deprecated(v doc.Version) {
+	w.printf("> %s\n\n", w.sprintf(locale.OutputDeprecated, v))
+}
+
+// 不论是 html 还是 markdown 格式，都可以直接作为 Markdown 的内容。
+//
+// 但是缩进在 Markdown 中有特殊的含义，html 需要去掉每一行的缩进，
+// markdown 则去掉所有行共同的缩进。
+func (w *writer) richtext(text doc.Richtext) {
+	var t string
+	if text.Type == doc.RichtextTypeHTML {
+		lines := strings.Split(text.Text, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSpace(line)
+		}
+		t = strings.TrimSpace(strings.Join(lines, "\n"))
+	} else {
+		t = dedent(text.Text)
+	}
+
+	if t != "" {
+		w.printf("%s\n\n", t)
+	}
+}
+
+// 去掉首尾的空行以及所有行共同的缩进
+func dedent(s string) string {
+	lines := strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	for i, line := range lines {
+		if len(line) >= indent {
+			lines[i] = line[indent:]
+		} else { // 仅包含空白字符的行
+			lines[i] = ""
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (w *writer) tableHeader(keys ...xmessage.Reference) {
+	cols := make([]string, 0, len(keys))
+	for _, key := range keys {
+		cols = append(cols, w.sprintf(key))
+	}
+	w.tableRow(cols...)
+	w.printf("|%s\n", strings.Repeat(" --- |", len(keys)))
+}
+
+func (w *writer) tableRow(cols ...string) {
+	w.printf("|")
+	for _, col := range cols {
+		w.printf(" %s |", cell(col))
+	}
+	w.printf("\n")
+}
+
+// 转义表格单元格中的内容
+var cellReplacer = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func cell(s string) string {
+	return cellReplacer.Replace(strings.TrimSpace(s))
+}
+
+func appendLine(s, line string) string {
+	switch {
+	case line == "" || line == s:
+		return s
+	case s == "":
+		return line
+	default:
+		return s + "\n" + line
+	}
+}
+
+func code(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + s + "`"
+}
+
+func typeName(t doc.Type, array bool) string {
+	if array {
+		return string(t) + "[]"
+	}
+	return string(t)
+}
+
+func apiTitle(api *doc.API) string {
+	if api.Summary != "" {
+		return api.Summary
+	}
+	return string(api.Method) + " " + api.Path.Path
+}
+
+// 根据请求方法和路径生成 API 的锚点名称，比如 get-users-id
+func apiAnchor(api *doc.API) string {
+	if api.ID != "" {
+		return api.ID
+	}
+
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(string(api.Method) + "/" + api.Path.Path) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// 根据 mimetype 确定代码块的语言
+func codeLang(mimetype string) string {
+	switch {
+	case strings.HasSuffix(mimetype, "json"):
+		return "json"
+	case strings.HasSuffix(mimetype, "xml"):
+		return "xml"
+	case strings.HasSuffix(mimetype, "yaml"):
+		return "yaml"
+	case strings.HasPrefix(mimetype, "text/html"):
+		return "html"
+	default:
+		return ""
+	}
+}
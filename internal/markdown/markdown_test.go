@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/doc/doctest"
+)
+
+func TestMarshal(t *testing.T) {
+	a := assert.New(t)
+
+	data, err := Marshal(doctest.Get())
+	a.NotError(err).NotEmpty(data)
+	text := string(data)
+
+	a.True(strings.HasPrefix(text, "# test 1.0.1\n")).
+		True(strings.Contains(text, "- [t1](#tag-t1)\n  - [GET /users](#get-users)\n  - [summary](#post-users) `POST /users`\n")).
+		True(strings.Contains(text, "| admin | https://example.com/admin | admin |\n")).
+		True(strings.Contains(text, "| GET | `/users` | admin, client | t1, t2 |  |\n")).
+		True(strings.Contains(text, "| `name` | `string` | ✓ |  | summary |\n")).
+		True(strings.Contains(text, "```json\nxxx\n```\n"))
+
+	// 多个标签的 API 只输出一次
+	a.Equal(1, strings.Count(text, `<a name="post-users"></a>`)).
+		Equal(2, strings.Count(text, "(#post-users)"))
+
+	// 未分类
+	d := doctest.Get()
+	d.Tags = nil
+	data, err = Marshal(d)
+	a.NotError(err)
+	a.True(strings.Contains(string(data), `<a name="untagged"></a>`))
+}
+
+func TestWriter_paramRows(t *testing.T) {
+	a := assert.New(t)
+
+	w := &writer{langID: "zh-Hans"}
+	rows := w.paramRows(nil, "", []*doc.Param{
+		{
+			Name:  "user",
+			Type:  doc.Object,
+			Array: true,
+			Items: []*doc.Param{
+				{Name: "name", Type: doc.String, Summary: "name", Default: "abc"},
+				{
+					Name:       "sex",
+					Type:       doc.String,
+					Summary:    "sex",
+					Optional:   true,
+					Deprecated: "1.0.0",
+					Enums: []*doc.Enum{
+						{Value: "male", Summary: "male"},
+						{Value: "female", Summary: "female"},
+					},
+				},
+			},
+		},
+	})
+	a.Equal(rows, [][]string{
+		{"`user`", "`object[]`", "✓", "", ""},
+		{"`user.name`", "`string`", "", "`abc`", "name"},
+		{"`user.sex`", "`string`", "", "", "sex\n`male` male\n`female` female\n自 1.0.0 起已废弃"},
+	})
+}
+
+func TestDedent(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(dedent(""), "")
+	a.Equal(dedent("\n\n  abc\n"), "abc")
+	a.Equal(dedent("\n    {\n        \"id\": 1\n  \n    }\n    "), "{\n    \"id\": 1\n\n}")
+	a.Equal(dedent("abc\n    def"), "abc\n    def")
+}
+
+func TestAPIAnchor(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(apiAnchor(&doc.API{Method: "GET", Path: &doc.Path{Path: "/users/{id}/logs"}}), "get-users-id-logs")
+	a.Equal(apiAnchor(&doc.API{Method: "GET", Path: &doc.Path{Path: "/"}}), "get")
+	a.Equal(apiAnchor(&doc.API{ID: "get-user", Method: "GET", Path: &doc.Path{Path: "/"}}), "get-user")
+}
+
+func TestCell(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(cell(" a|b\nc\r\nd "), `a\|b<br>c<br>d`)
+}
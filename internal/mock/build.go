@@ -3,12 +3,18 @@
 package mock
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/textproto"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/issue9/is"
+	"github.com/issue9/mux/v2"
 	"github.com/issue9/qheader"
 
 	"github.com/caixw/apidoc/v6/doc"
@@ -17,6 +23,8 @@ import (
 	"github.com/caixw/apidoc/v6/message"
 )
 
+var uuidExpr = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
 func (m *Mock) buildAPI(api *doc.API) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		m.h.Message(message.Succ, locale.RequestAPI, r.Method, r.URL.Path)
@@ -24,20 +32,48 @@ func (m *Mock) buildAPI(api *doc.API) http.Handler {
 			m.h.Message(message.Warn, locale.DeprecatedWarn, r.Method, r.URL.Path, api.Deprecated)
 		}
 
+		if len(api.Security) > 0 {
+			if err := m.validSecurity(api.Security, r); err != nil {
+				m.handleStatusError(w, r, http.StatusUnauthorized, "security", err)
+				return
+			}
+		}
+
+		params := mux.Params(r)
+		for _, param := range api.Path.Params {
+			val, found := params[param.Name]
+			if err := validParam(param, val, found); err != nil {
+				m.handleError(w, r, "params["+param.Name+"]", err)
+				return
+			}
+		}
+
+		queries := r.URL.Query()
 		for _, query := range api.Path.Queries {
-			if err := validParam(query, r.FormValue(query.Name)); err != nil {
+			// 数组可以是多个同名的参数，也可以是以逗号分隔的值
+			vals, found := queries[query.Name]
+			if err := validParam(query, strings.Join(vals, ","), found); err != nil {
 				m.handleError(w, r, "queries["+query.Name+"]", err)
 				return
 			}
 		}
 
 		for _, header := range api.Headers {
-			if err := validParam(header, r.Header.Get(header.Name)); err != nil {
+			val, found := getHeader(r, header.Name)
+			if err := validParam(header, val, found); err != nil {
 				m.handleError(w, r, "headers["+header.Name+"]", err)
 				return
 			}
 		}
 
+		for _, cookie := range api.Cookies {
+			val, found := getCookie(r, cookie.Name)
+			if err := validParam(cookie, val, found); err != nil {
+				m.handleError(w, r, "cookies["+cookie.Name+"]", err)
+				return
+			}
+		}
+
 		if len(api.Requests) > 0 { // GET、OPTIONS 之类的可能没有 body
 			if err := validRequest(api.Requests, r); err != nil {
 				m.handleError(w, r, "request.body.", err)
@@ -49,6 +85,66 @@ func (m *Mock) buildAPI(api *doc.API) http.Handler {
 	})
 }
 
+// 验证请求中是否包含身份验证的凭证
+//
+// 仅验证凭证是否存在，不验证其值是否正确。
+// requirements 之间为或的关系，满足其中之一即可。
+func (m *Mock) validSecurity(requirements []*doc.SecurityRequirement, r *http.Request) (err error) {
+	for _, req := range requirements {
+		for _, s := range m.doc.Security {
+			if s.Name != req.Name {
+				continue
+			}
+
+			if err = validCredential(s, r); err == nil {
+				return nil
+			}
+			break
+		}
+	}
+
+	return err
+}
+
+func validCredential(s *doc.Security, r *http.Request) error {
+	switch s.Type {
+	case doc.SecurityAPIKey:
+		var val string
+		switch s.IN {
+		case doc.SecurityInHeader:
+			val = r.Header.Get(s.Key)
+		case doc.SecurityInQuery:
+			val = r.URL.Query().Get(s.Key)
+		case doc.SecurityInCookie:
+			val, _ = getCookie(r, s.Key)
+		}
+
+		if val == "" {
+			return message.NewLocaleError("", "["+s.Name+"]", 0, locale.ErrRequired)
+		}
+		return nil
+	case doc.SecurityHTTP:
+		return validAuthorization(s.Name, s.Scheme, r)
+	default: // oauth2 和 openidconnect 都是以 bearer 的方式传递 access token
+		return validAuthorization(s.Name, "bearer", r)
+	}
+}
+
+// 验证 Authorization 报头是否以 scheme 开头
+func validAuthorization(name, scheme string, r *http.Request) error {
+	auth := r.Header.Get("Authorization")
+	if auth == "" {
+		return message.NewLocaleError("", "["+name+"]", 0, locale.ErrRequired)
+	}
+
+	prefix := scheme + " "
+	if len(auth) <= len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return message.NewLocaleError("", "["+name+"]", 0, locale.ErrInvalidFormat)
+	}
+
+	return nil
+}
+
 func validRequest(requests []*doc.Request, r *http.Request) error {
 	ct := r.Header.Get("Content-Type")
 	if ct == "" || ct == "*/*" || strings.HasSuffix(ct, "/*") { // 用户提交的 content-type 必须是明确的值
@@ -60,7 +156,15 @@ func validRequest(requests []*doc.Request, r *http.Request) error {
 	}
 
 	for _, header := range req.Headers {
-		if err := validParam(header, r.Header.Get(header.Name)); err != nil {
+		val, found := getHeader(r, header.Name)
+		if err := validParam(header, val, found); err != nil {
+			return err
+		}
+	}
+
+	for _, cookie := range req.Cookies {
+		val, found := getCookie(r, cookie.Name)
+		if err := validParam(cookie, val, found); err != nil {
 			return err
 		}
 	}
@@ -109,17 +213,21 @@ func (m *Mock) renderResponse(api *doc.API, w http.ResponseWriter, r *http.Reque
 	w.Header().Set("Content-Type", accept)
 	w.Header().Set("Server", vars.Name)
 	for _, item := range resp.Headers {
-		switch item.Type {
-		case doc.Bool:
-			w.Header().Set(item.Name, strconv.FormatBool(generateBool()))
-		case doc.Number:
-			w.Header().Set(item.Name, strconv.FormatInt(generateNumber(item), 10))
-		case doc.String:
-			w.Header().Set(item.Name, generateString(item))
-		default:
+		val, ok := generateSimpleValue(item)
+		if !ok {
 			m.handleError(w, r, "response.headers", locale.Errorf(locale.ErrInvalidFormat))
 			return
 		}
+		w.Header().Set(item.Name, val)
+	}
+
+	for _, item := range resp.Cookies {
+		val, ok := generateSimpleValue(item)
+		if !ok {
+			m.handleError(w, r, "response.cookies", locale.Errorf(locale.ErrInvalidFormat))
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: item.Name, Value: val, Path: "/"})
 	}
 
 	w.WriteHeader(int(resp.Status))
@@ -128,6 +236,38 @@ func (m *Mock) renderResponse(api *doc.API, w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// 生成报头和 cookie 等简单类型的值，如果 p 不是简单类型，则返回 false。
+func generateSimpleValue(p *doc.Param) (string, bool) {
+	switch p.Type {
+	case doc.Bool:
+		return strconv.FormatBool(generateBool()), true
+	case doc.Number, doc.Integer, doc.Float:
+		return fmt.Sprint(generateNumberValue(p)), true
+	case doc.String:
+		return generateString(p), true
+	default:
+		return "", false
+	}
+}
+
+// 获取名为 name 的 cookie 值，不存在时返回空值和 false。
+func getCookie(r *http.Request, name string) (string, bool) {
+	c, err := r.Cookie(name)
+	if err == http.ErrNoCookie {
+		return "", false
+	}
+	return c.Value, true
+}
+
+// 获取名为 name 的报头，不存在时返回空值和 false。
+func getHeader(r *http.Request, name string) (string, bool) {
+	vals, found := r.Header[textproto.CanonicalMIMEHeaderKey(name)]
+	if !found || len(vals) == 0 {
+		return "", false
+	}
+	return vals[0], true
+}
+
 func findRequestByContentType(requests []*doc.Request, ct string) *doc.Request {
 	var none *doc.Request
 	for _, req := range requests {
@@ -205,6 +345,11 @@ func findResponseByAccept(mimetypes []string, requests []*doc.Request, accepts [
 
 // 处理 serveHTTP 中的错误
 func (m *Mock) handleError(w http.ResponseWriter, r *http.Request, field string, err error) {
+	m.handleStatusError(w, r, http.StatusBadRequest, field, err)
+}
+
+// 处理 serveHTTP 中的错误，并以 status 作为状态码输出
+func (m *Mock) handleStatusError(w http.ResponseWriter, r *http.Request, status int, field string, err error) {
 	file := r.Method + " " + r.URL.Path
 
 	if serr, ok := err.(*message.SyntaxError); ok {
@@ -220,31 +365,53 @@ func (m *Mock) handleError(w http.ResponseWriter, r *http.Request, field string,
 	}
 
 	m.h.Error(message.Erro, err)
-	w.WriteHeader(http.StatusBadRequest)
+	w.WriteHeader(status)
 }
 
 // 验证单个参数
-func validParam(p *doc.Param, val string) error {
+//
+// found 表示参数是否已经提交，未提交的参数如果有默认值，则以默认值进行验证；
+// 数组以逗号分隔各个元素，每个元素都需要符合 p 的要求。
+func validParam(p *doc.Param, val string, found bool) error {
 	if p == nil {
 		return nil
 	}
 
-	if val == "" && p.Type != doc.String { // 字符串的默认值可以为 “”
-		if p.Optional || p.Default != "" {
+	if !found || val == "" {
+		switch {
+		case p.Default != "":
+			val = p.Default
+		case p.Optional:
 			return nil
+		case !found || p.Type != doc.String: // 已提交的字符串可以为 “”
+			return message.NewLocaleError("", "", 0, locale.ErrRequired)
 		}
+	}
 
-		return message.NewLocaleError("", "", 0, locale.ErrRequired)
+	if !p.Array {
+		return validParamValue(p, val)
 	}
 
-	// TODO 如何验证数组的值？
+	vals := strings.Split(val, ",")
+	if err := validItems(p, "", len(vals)); err != nil {
+		return err
+	}
+	for _, v := range vals {
+		if err := validParamValue(p, strings.TrimSpace(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+// 验证单个值是否符合 p 的要求，不考虑 p.Array 的值。
+func validParamValue(p *doc.Param, val string) error {
 	switch p.Type {
 	case doc.Bool:
 		if _, err := strconv.ParseBool(val); err != nil {
 			return message.NewLocaleError("", "", 0, locale.ErrInvalidFormat)
 		}
-	case doc.Number:
+	case doc.Number, doc.Integer, doc.Float:
 		if !is.Number(val) {
 			return message.NewLocaleError("", "", 0, locale.ErrInvalidFormat)
 		}
@@ -268,8 +435,79 @@ func validParam(p *doc.Param, val string) error {
 		if !found {
 			return message.NewLocaleError("", "", 0, locale.ErrInvalidValue)
 		}
+		return nil
 	}
 
+	return validConstraint(p, "", val)
+}
+
+// 验证 val 是否符合 p 的约束条件
+//
+// 仅对数值和字符串类型有效，其它类型直接返回 nil。
+func validConstraint(p *doc.Param, field, val string) error {
+	switch {
+	case p.Type.IsNumber():
+		var v float64
+		if p.Type == doc.Integer {
+			i, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return message.NewLocaleError("", field, 0, locale.ErrInvalidFormat)
+			}
+			v = float64(i)
+		} else {
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return message.NewLocaleError("", field, 0, locale.ErrInvalidFormat)
+			}
+			v = f
+		}
+
+		if (p.Min != nil && v < *p.Min) || (p.Max != nil && v > *p.Max) {
+			return message.NewLocaleError("", field, 0, locale.ErrInvalidValue)
+		}
+	case p.Type == doc.String:
+		size := utf8.RuneCountInString(val)
+		if size < p.MinLength || (p.MaxLength > 0 && size > p.MaxLength) {
+			return message.NewLocaleError("", field, 0, locale.ErrInvalidValue)
+		}
+
+		expr, err := p.Regexp()
+		if err != nil || (expr != nil && !expr.MatchString(val)) {
+			return message.NewLocaleError("", field, 0, locale.ErrInvalidFormat)
+		}
+
+		if !validFormat(p.Format, val) {
+			return message.NewLocaleError("", field, 0, locale.ErrInvalidFormat)
+		}
+	}
+
+	return nil
+}
+
+func validFormat(format, val string) bool {
+	switch format {
+	case doc.FormatDate:
+		_, err := time.Parse("2006-01-02", val)
+		return err == nil
+	case doc.FormatDateTime:
+		_, err := time.Parse(time.RFC3339, val)
+		return err == nil
+	case doc.FormatEmail:
+		return is.Email(val)
+	case doc.FormatURI:
+		return is.URL(val)
+	case doc.FormatUUID:
+		return uuidExpr.MatchString(val)
+	default: // binary 以及未指定
+		return true
+	}
+}
+
+// 验证数组 p 的元素数量是否符合要求
+func validItems(p *doc.Param, field string, size int) error {
+	if size < p.MinItems || (p.MaxItems > 0 && size > p.MaxItems) {
+		return message.NewLocaleError("", field, 0, locale.ErrInvalidValue)
+	}
 	return nil
 }
 
@@ -278,8 +516,8 @@ func buildResponse(p *doc.Request, r *http.Request) ([]byte, error) {
 		return nil, nil
 	}
 
-	for _, header := range p.Headers {
-		if err := validParam(header, r.Header.Get(header.Name)); err != nil {
+	for _, header := range p.Headers { // 仅验证已提交的报头
+		if err := validParam(header, r.Header.Get(header.Name), true); err != nil {
 			return nil, err
 		}
 	}
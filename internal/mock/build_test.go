@@ -247,10 +247,11 @@ func TestValidParam(t *testing.T) {
 	a := assert.New(t)
 
 	data := []*struct {
-		title string
-		p     *doc.Param
-		v     string
-		err   bool
+		title   string
+		p       *doc.Param
+		v       string
+		missing bool // 参数未提交
+		err     bool
 	}{
 		{
 			title: "nil",
@@ -326,10 +327,86 @@ func TestValidParam(t *testing.T) {
 			v:     "-xxx10.2",
 			err:   true,
 		},
+		{
+			title: "integer",
+			p:     &doc.Param{Type: doc.Integer},
+			v:     "10",
+		},
+		{
+			title: "integer failed",
+			p:     &doc.Param{Type: doc.Integer},
+			v:     "10.2",
+			err:   true,
+		},
+		{
+			title: "string with optional and min-length",
+			p:     &doc.Param{Type: doc.String, Optional: true, Constraint: doc.Constraint{MinLength: 5}},
+			v:     "",
+		},
+		{
+			title: "string with min-length failed",
+			p:     &doc.Param{Type: doc.String, Constraint: doc.Constraint{MinLength: 5}},
+			v:     "1024",
+			err:   true,
+		},
+		{
+			title:   "missing string",
+			p:       &doc.Param{Type: doc.String},
+			missing: true,
+			err:     true,
+		},
+		{
+			title:   "missing optional string",
+			p:       &doc.Param{Type: doc.String, Optional: true},
+			missing: true,
+		},
+		{
+			title:   "missing string with default",
+			p:       &doc.Param{Type: doc.String, Default: "def"},
+			missing: true,
+		},
+		{
+			title: "number with default",
+			p:     &doc.Param{Type: doc.Number, Default: "5"},
+			v:     "",
+		},
+		{
+			title: "number with invalid default",
+			p:     &doc.Param{Type: doc.Number, Default: "xx", Optional: true},
+			v:     "",
+			err:   true,
+		},
+		{
+			title: "number array",
+			p:     &doc.Param{Type: doc.Number, Array: true},
+			v:     "1, 2,3",
+		},
+		{
+			title: "number array failed",
+			p:     &doc.Param{Type: doc.Number, Array: true},
+			v:     "1,x,3",
+			err:   true,
+		},
+		{
+			title: "array with enum failed",
+			p: &doc.Param{
+				Type:  doc.String,
+				Array: true,
+				Enums: []*doc.Enum{{Value: "a"}, {Value: "b"}},
+			},
+			v:   "a,c",
+			err: true,
+		},
+		{
+			title: "array with max-items failed",
+			p:     &doc.Param{Type: doc.Number, Array: true, Constraint: doc.Constraint{MaxItems: 2}},
+			v:     "1,2,3",
+			err:   true,
+		},
 	}
 
 	for _, item := range data {
-		err := validParam(item.p, item.v)
+		err := validParam(item.p, item.v, !item.missing)
 		if item.err {
 			a.Error(err, "%s 并未返回错误值", item.title)
 		} else {
@@ -337,3 +414,107 @@ func TestValidParam(t *testing.T) {
 		}
 	}
 }
+
+func TestMock_validSecurity(t *testing.T) {
+	a := assert.New(t)
+
+	m := &Mock{doc: &doc.Doc{
+		Security: []*doc.Security{
+			{Name: "header", Type: doc.SecurityAPIKey, IN: doc.SecurityInHeader, Key: "X-Token"},
+			{Name: "query", Type: doc.SecurityAPIKey, IN: doc.SecurityInQuery, Key: "token"},
+			{Name: "cookie", Type: doc.SecurityAPIKey, IN: doc.SecurityInCookie, Key: "token"},
+			{Name: "basic", Type: doc.SecurityHTTP, Scheme: "basic"},
+			{Name: "oauth", Type: doc.SecurityOAuth2},
+		},
+	}}
+
+	r := httptest.NewRequest(http.MethodGet, "/path", nil)
+	a.Error(m.validSecurity([]*doc.SecurityRequirement{{Name: "header"}}, r))
+	r.Header.Set("X-Token", "xxx")
+	a.NotError(m.validSecurity([]*doc.SecurityRequirement{{Name: "header"}}, r))
+
+	r = httptest.NewRequest(http.MethodGet, "/path?token=xxx", nil)
+	a.NotError(m.validSecurity([]*doc.SecurityRequirement{{Name: "query"}}, r))
+	a.Error(m.validSecurity([]*doc.SecurityRequirement{{Name: "cookie"}}, r))
+
+	r = httptest.NewRequest(http.MethodGet, "/path", nil)
+	r.AddCookie(&http.Cookie{Name: "token", Value: "xxx"})
+	a.NotError(m.validSecurity([]*doc.SecurityRequirement{{Name: "cookie"}}, r))
+
+	// 多个之间为或的关系
+	r = httptest.NewRequest(http.MethodGet, "/path", nil)
+	r.Header.Set("Authorization", "Basic xxx")
+	a.NotError(m.validSecurity([]*doc.SecurityRequirement{{Name: "header"}, {Name: "basic"}}, r))
+	a.Error(m.validSecurity([]*doc.SecurityRequirement{{Name: "header"}, {Name: "oauth"}}, r))
+
+	r = httptest.NewRequest(http.MethodGet, "/path", nil)
+	r.Header.Set("Authorization", "bearer xxx")
+	a.NotError(m.validSecurity([]*doc.SecurityRequirement{{Name: "oauth"}}, r))
+
+	r.Header.Set("Authorization", "bearer ")
+	a.Error(m.validSecurity([]*doc.SecurityRequirement{{Name: "oauth"}}, r))
+}
+
+func TestValidConstraint(t *testing.T) {
+	a := assert.New(t)
+
+	min := 1.0
+	max := 10.0
+
+	// 数值
+	p := &doc.Param{Type: doc.Integer, Constraint: doc.Constraint{Min: &min, Max: &max}}
+	a.NotError(validConstraint(p, "", "1"))
+	a.NotError(validConstraint(p, "", "10"))
+	a.Error(validConstraint(p, "", "0"))
+	a.Error(validConstraint(p, "", "11"))
+	a.Error(validConstraint(p, "", "1.5"))
+
+	p = &doc.Param{Type: doc.Float, Constraint: doc.Constraint{Max: &max}}
+	a.NotError(validConstraint(p, "", "1.5"))
+	a.NotError(validConstraint(p, "", "-1.5"))
+	a.Error(validConstraint(p, "", "10.5"))
+	a.Error(validConstraint(p, "", "x"))
+
+	// 字符串
+	p = &doc.Param{Type: doc.String, Constraint: doc.Constraint{MinLength: 2, MaxLength: 3, Pattern: "^[a-z]+$"}}
+	a.NotError(validConstraint(p, "", "ab"))
+	a.NotError(validConstraint(p, "", "abc"))
+	a.Error(validConstraint(p, "", "a"))
+	a.Error(validConstraint(p, "", "abcd"))
+	a.Error(validConstraint(p, "", "a1"))
+
+	// 中文按字符计算长度
+	p = &doc.Param{Type: doc.String, Constraint: doc.Constraint{MaxLength: 2}}
+	a.NotError(validConstraint(p, "", "中文"))
+
+	data := map[string][]string{
+		doc.FormatDate:     {"2020-01-02", "2020-13-02"},
+		doc.FormatDateTime: {"2020-01-02T15:04:05Z", "2020-01-02"},
+		doc.FormatEmail:    {"name@example.com", "name"},
+		doc.FormatURI:      {"https://example.com", "example"},
+		doc.FormatUUID:     {"00000000-0000-4000-8000-000000001024", "00000000-0000"},
+	}
+	for format, vals := range data {
+		p = &doc.Param{Type: doc.String, Constraint: doc.Constraint{Format: format}}
+		a.NotError(validConstraint(p, "", vals[0]), "%s 返回了错误", format)
+		a.Error(validConstraint(p, "", vals[1]), "%s 未返回错误", format)
+	}
+
+	// 其它类型不作检测
+	p = &doc.Param{Type: doc.Bool, Constraint: doc.Constraint{MinLength: 5}}
+	a.NotError(validConstraint(p, "", "true"))
+}
+
+func TestValidItems(t *testing.T) {
+	a := assert.New(t)
+
+	p := &doc.Param{Array: true}
+	a.NotError(validItems(p, "", 0))
+	a.NotError(validItems(p, "", 100))
+
+	p = &doc.Param{Array: true, Constraint: doc.Constraint{MinItems: 1, MaxItems: 2}}
+	a.NotError(validItems(p, "", 1))
+	a.NotError(validItems(p, "", 2))
+	a.Error(validItems(p, "", 0))
+	a.Error(validItems(p, "", 3))
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/caixw/apidoc/v6/doc"
@@ -27,6 +28,9 @@ type jsonValidator struct {
 
 	// 按顺序保存变量名称
 	names []string
+
+	// 按顺序保存各个数组中元素的数量
+	items []int
 }
 
 type jsonBuilder struct {
@@ -44,7 +48,7 @@ func validJSON(p *doc.Request, content []byte) error {
 		return message.NewLocaleError("", "", 0, locale.ErrInvalidFormat)
 	}
 
-	if (p.Type == doc.None) && len(content) == 0 {
+	if (p.Type == doc.None && !p.IsUnion()) && len(content) == 0 {
 		return nil
 	}
 
@@ -52,14 +56,78 @@ func validJSON(p *doc.Request, content []byte) error {
 		return message.NewLocaleError("", "", 0, locale.ErrInvalidFormat)
 	}
 
+	return validJSONParam(p.Param(), "", content)
+}
+
+// 验证 content 是否符合 p 的定义
+//
+// field 表示 p 在整个对象中的位置，会作为错误信息中字段的前缀。
+func validJSONParam(p *doc.Param, field string, content []byte) error {
 	validator := &jsonValidator{
-		param:   p.Param(),
+		param:   p,
 		decoder: json.NewDecoder(bytes.NewReader(content)),
 		states:  []byte{}, // 状态有默认值
 		names:   []string{},
+		items:   []int{},
 	}
 
-	return validator.valid()
+	err := validator.valid()
+	if serr, ok := err.(*message.SyntaxError); ok && field != "" {
+		if serr.Field == "" {
+			serr.Field = field
+		} else {
+			serr.Field = field + "." + serr.Field
+		}
+	}
+	return err
+}
+
+// 验证 content 是否符合组合类型 p 中的某一个子类型
+func validJSONUnion(p *doc.Param, field string, content []byte) error {
+	if bytes.Equal(content, []byte("null")) {
+		if p.Nullable {
+			return nil
+		}
+		return message.NewLocaleError("", field, 0, locale.ErrInvalidValue)
+	}
+
+	u, one := p.OneOf, true
+	if u == nil {
+		u, one = p.AnyOf, false
+	}
+
+	if u.Discriminator != "" {
+		obj := map[string]json.RawMessage{}
+		if err := json.Unmarshal(content, &obj); err != nil {
+			return message.NewLocaleError("", field, 0, locale.ErrInvalidFormat)
+		}
+
+		var name string
+		if err := json.Unmarshal(obj[u.Discriminator], &name); err == nil {
+			for _, item := range u.Items {
+				if item.Name == name {
+					return validJSONParam(item, field, content)
+				}
+			}
+		}
+
+		if field != "" {
+			field += "."
+		}
+		return message.NewLocaleError("", field+u.Discriminator, 0, locale.ErrInvalidValue)
+	}
+
+	matched := 0
+	for _, item := range u.Items {
+		if validJSONParam(item, field, content) == nil {
+			matched++
+		}
+	}
+
+	if matched == 0 || (one && matched > 1) {
+		return message.NewLocaleError("", field, 0, locale.ErrInvalidValue)
+	}
+	return nil
 }
 
 func (validator *jsonValidator) valid() error {
@@ -73,26 +141,38 @@ func (validator *jsonValidator) valid() error {
 			return err
 		}
 
+		if p := validator.union(token); p != nil {
+			if err = validator.validUnion(p, token); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if token == nil { // 对应 JSON null
+			validator.incrItems()
 			if err = validator.validValue("", nil); err != nil {
 				return err
 			}
-			validator.popState()
-			validator.popName()
+			if validator.state() != '[' {
+				validator.popState()
+				validator.popName()
+			}
 		}
 
 		switch v := token.(type) {
 		case string: // json string
 			switch validator.state() {
-			case ':': // 字符串类型的值
+			case '{': // 属性名
+				validator.pushState(':')
+				validator.pushName(v)
+				err = validator.validReadOnly()
+			case '[':
+				validator.incrItems()
+				err = validator.validValue(doc.String, v)
+			default: // 字符串类型的值，包括顶层的值
 				err = validator.validValue(doc.String, v)
 				validator.popState()
 				validator.popName()
-			case '[':
-				err = validator.validValue(doc.String, v)
-			default: // 属性名
-				validator.pushState(':')
-				validator.pushName(v)
 			}
 
 			if err != nil {
@@ -101,31 +181,36 @@ func (validator *jsonValidator) valid() error {
 		case json.Delim: // [、]、{、}
 			switch v {
 			case '[':
+				validator.incrItems()
 				validator.pushState('[')
+				validator.items = append(validator.items, 0)
 			case ']':
-				validator.popName()
+				err = validator.validItems()
 
 				validator.popState()
 				if validator.state() == ':' { // {xx: [] } 类似这种格式，需要同时弹出两个状态
 					validator.popState()
+					validator.popName()
 				}
 			case '{':
+				validator.incrItems()
 				validator.pushState('{')
 			case '}':
-				validator.popName()
-
 				validator.popState()
 				if validator.state() == ':' {
 					validator.popState()
+					validator.popName()
 				}
 			}
 		case bool: // json bool
+			validator.incrItems()
 			err = validator.validValue(doc.Bool, v)
 			if validator.state() != '[' {
 				validator.popState()
 				validator.popName()
 			}
 		case float64, json.Number: // json number
+			validator.incrItems()
 			err = validator.validValue(doc.Number, v)
 			if validator.state() != '[' { // 只有键值对结束时，才弹出键名
 				validator.popState()
@@ -139,7 +224,103 @@ func (validator *jsonValidator) valid() error {
 	}
 }
 
-// 如果 t == "" 表示不需要验证类型，比如 null 可以赋值给任何类型
+// 如果 token 是组合类型的值，返回该组合类型，否则返回 nil
+func (validator *jsonValidator) union(token json.Token) *doc.Param {
+	switch v := token.(type) {
+	case json.Delim:
+		if v == ']' || v == '}' {
+			return nil
+		}
+	case string:
+		if validator.state() == '{' { // 属性名
+			return nil
+		}
+	}
+
+	p := validator.find()
+	if p == nil || !p.IsUnion() {
+		return nil
+	}
+
+	// 数组本身，而不是数组中的元素
+	if d, ok := token.(json.Delim); ok && d == '[' && p.Array && validator.state() != '[' {
+		return nil
+	}
+
+	return p
+}
+
+// 读取以 token 开头的完整值，并验证其是否符合组合类型 p 的定义
+func (validator *jsonValidator) validUnion(p *doc.Param, token json.Token) error {
+	validator.incrItems()
+
+	content, err := validator.readValue(token)
+	if err != nil {
+		return err
+	}
+
+	if err := validJSONUnion(p, strings.Join(validator.names, "."), content); err != nil {
+		return err
+	}
+
+	if validator.state() == ':' {
+		validator.popState()
+		validator.popName()
+	}
+	return nil
+}
+
+// 读取以 token 开头的完整值，并重新编码成 JSON
+func (validator *jsonValidator) readValue(token json.Token) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	type frame struct {
+		delim json.Delim
+		count int // 已经写入的元素数量，对象的键名和值分别计数
+	}
+	frames := make([]*frame, 0, 10)
+
+	for {
+		if d, ok := token.(json.Delim); ok && (d == '}' || d == ']') {
+			buf.WriteRune(rune(d))
+			frames = frames[:len(frames)-1]
+		} else {
+			if len(frames) > 0 {
+				f := frames[len(frames)-1]
+				switch {
+				case f.delim == '{' && f.count%2 == 1:
+					buf.WriteByte(':')
+				case f.count > 0:
+					buf.WriteByte(',')
+				}
+				f.count++
+			}
+
+			if d, ok := token.(json.Delim); ok {
+				buf.WriteRune(rune(d))
+				frames = append(frames, &frame{delim: d})
+			} else {
+				data, err := json.Marshal(token)
+				if err != nil {
+					return nil, err
+				}
+				buf.Write(data)
+			}
+		}
+
+		if len(frames) == 0 {
+			return buf.Bytes(), nil
+		}
+
+		t, err := validator.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		token = t
+	}
+}
+
+// 如果 t == "" 表示 null，仅 nullable 的字段可以赋值为 null
 func (validator *jsonValidator) validValue(t doc.Type, v interface{}) error {
 	field := strings.Join(validator.names, ".")
 
@@ -148,26 +329,64 @@ func (validator *jsonValidator) validValue(t doc.Type, v interface{}) error {
 		return message.NewLocaleError("", field, 0, locale.ErrNotFound)
 	}
 
-	if t == "" {
+	if t == "" { // null
+		if !p.Nullable {
+			return message.NewLocaleError("", field, 0, locale.ErrInvalidValue)
+		}
 		return nil
 	}
 
-	if p.Type != t {
+	if (t == doc.Number && !p.Type.IsNumber()) || (t != doc.Number && p.Type != t) {
 		return message.NewLocaleError("", field, 0, locale.ErrInvalidFormat)
 	}
 
+	val := fmt.Sprint(v)
+	if f, ok := v.(float64); ok {
+		val = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
 	if p.IsEnum() {
 		for _, enum := range p.Enums {
-			if enum.Value == fmt.Sprint(v) {
+			if enum.Value == val {
 				return nil
 			}
 		}
 		return message.NewLocaleError("", field, 0, locale.ErrInvalidValue)
 	}
 
+	return validConstraint(p, field, val)
+}
+
+// 请求中不能包含只读的字段
+func (validator *jsonValidator) validReadOnly() error {
+	if p := validator.find(); p != nil && p.ReadOnly {
+		return message.NewLocaleError("", strings.Join(validator.names, "."), 0, locale.ErrInvalidValue)
+	}
 	return nil
 }
 
+// 如果当前处于数组中，则增加数组元素的数量
+func (validator *jsonValidator) incrItems() {
+	if validator.state() == '[' && len(validator.items) > 0 {
+		validator.items[len(validator.items)-1]++
+	}
+}
+
+// 验证当前数组的元素数量，并弹出该数量。
+func (validator *jsonValidator) validItems() error {
+	if len(validator.items) == 0 {
+		return nil
+	}
+	size := validator.items[len(validator.items)-1]
+	validator.items = validator.items[:len(validator.items)-1]
+
+	p := validator.find()
+	if p == nil {
+		return nil
+	}
+	return validItems(p, strings.Join(validator.names, "."), size)
+}
+
 // 返回当前的状态
 func (validator *jsonValidator) state() byte {
 	if len(validator.states) > 0 {
@@ -204,6 +423,11 @@ func (validator *jsonValidator) popName() *jsonValidator {
 func (validator *jsonValidator) find() *doc.Param {
 	p := validator.param
 	for _, name := range validator.names {
+		if v := p.MapValue(); v != nil { // 字典的键名可以是任意值
+			p = v
+			continue
+		}
+
 		found := false
 		for _, pp := range p.Items {
 			if pp.Name == name {
@@ -273,7 +497,7 @@ func (builder *jsonBuilder) writeValue(v interface{}) *jsonBuilder {
 }
 
 func buildJSON(p *doc.Request) ([]byte, error) {
-	if p != nil && p.Type == doc.None {
+	if p != nil && p.Type == doc.None && !p.IsUnion() {
 		return nil, nil
 	}
 
@@ -297,7 +521,7 @@ func writeJSON(builder *jsonBuilder, p *doc.Param, chkArray bool) error {
 	if p.Array && chkArray {
 		builder.writeStrings("[\n").incrIndent()
 
-		size := generateSliceSize()
+		size := generateSliceSize(p)
 		last := size - 1
 		for i := 0; i < size; i++ {
 			builder.writeIndent()
@@ -317,20 +541,32 @@ func writeJSON(builder *jsonBuilder, p *doc.Param, chkArray bool) error {
 		return builder.err
 	}
 
+	if p.IsUnion() {
+		return writeJSON(builder, generateUnion(p), false)
+	}
+
 	switch p.Type {
 	case doc.None:
 		builder.writeValue(nil)
 	case doc.Bool:
 		builder.writeValue(generateBool())
-	case doc.Number:
-		builder.writeValue(generateNumber(p))
+	case doc.Number, doc.Integer, doc.Float:
+		builder.writeValue(generateNumberValue(p))
 	case doc.String:
 		builder.writeValue(generateString(p))
 	case doc.Object:
 		builder.writeStrings("{\n").incrIndent()
 
-		last := len(p.Items) - 1
-		for index, item := range p.Items {
+		// 返回内容中不包含只写的字段
+		items := make([]*doc.Param, 0, len(p.Items))
+		for _, item := range p.Items {
+			if !item.WriteOnly {
+				items = append(items, item)
+			}
+		}
+
+		last := len(items) - 1
+		for index, item := range items {
 			builder.writeIndent().writeStrings(`"`, item.Name, `"`, ": ")
 
 			if err := writeJSON(builder, item, true); err != nil {
@@ -344,6 +580,26 @@ func writeJSON(builder *jsonBuilder, p *doc.Param, chkArray bool) error {
 			}
 		}
 
+		builder.decrIndent().writeIndent().writeStrings("}")
+	case doc.Map:
+		builder.writeStrings("{\n").incrIndent()
+
+		keys := generateMapKeys()
+		last := len(keys) - 1
+		for index, key := range keys {
+			builder.writeIndent().writeValue(key).writeStrings(": ")
+
+			if err := writeJSON(builder, p.MapValue(), true); err != nil {
+				return err
+			}
+
+			if index < last {
+				builder.writeStrings(",\n")
+			} else {
+				builder.writeStrings("\n")
+			}
+		}
+
 		builder.decrIndent().writeIndent().writeStrings("}")
 	}
 
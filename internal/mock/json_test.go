@@ -50,6 +50,23 @@ func TestJSONValidator_find(t *testing.T) {
 	a.NotNil(p).Equal(p.Type, doc.Number)
 }
 
+func TestJSONValidator_readValue(t *testing.T) {
+	a := assert.New(t)
+
+	content := `{"a":[1,{"b":null,"c":true}],"d":"x"} 5`
+	v := &jsonValidator{decoder: json.NewDecoder(strings.NewReader(content))}
+
+	token, err := v.decoder.Token()
+	a.NotError(err)
+	data, err := v.readValue(token)
+	a.NotError(err).Equal(string(data), `{"a":[1,{"b":null,"c":true}],"d":"x"}`)
+
+	token, err = v.decoder.Token()
+	a.NotError(err)
+	data, err = v.readValue(token)
+	a.NotError(err).Equal(string(data), `5`)
+}
+
 func TestValidJSON(t *testing.T) {
 	a := assert.New(t)
 
@@ -59,6 +76,173 @@ func TestValidJSON(t *testing.T) {
 	}
 }
 
+func TestValidJSON_constraint(t *testing.T) {
+	a := assert.New(t)
+
+	max := 10.0
+	req := &doc.Request{
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "id", Type: doc.Integer, Constraint: doc.Constraint{Max: &max}},
+			{Name: "price", Type: doc.Float},
+			{
+				Name:       "tags",
+				Type:       doc.Object,
+				Array:      true,
+				Constraint: doc.Constraint{MinItems: 1, MaxItems: 2},
+				Items:      []*doc.Param{{Name: "name", Type: doc.String}},
+			},
+			{Name: "name", Type: doc.String, Constraint: doc.Constraint{MaxLength: 3}},
+		},
+	}
+
+	a.NotError(validJSON(req, []byte(`{"id":5,"price":1.5,"tags":[{"name":"t1"},{"name":"t2"}],"name":"n"}`)))
+
+	// 整数
+	a.Error(validJSON(req, []byte(`{"id":5.5}`)))
+
+	// 超过最大值
+	a.Error(validJSON(req, []byte(`{"id":11}`)))
+
+	// 数组数量
+	a.Error(validJSON(req, []byte(`{"tags":[]}`)))
+	a.Error(validJSON(req, []byte(`{"tags":[{"name":"t1"},{"name":"t2"},{"name":"t3"}]}`)))
+
+	// 数组之后的字段依然可以正确查找
+	a.Error(validJSON(req, []byte(`{"tags":[{"name":"t1"}],"name":"long"}`)))
+}
+
+func TestValidJSON_modifier(t *testing.T) {
+	a := assert.New(t)
+
+	req := &doc.Request{
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "id", Type: doc.Number, ReadOnly: true},
+			{Name: "name", Type: doc.String, Nullable: true},
+			{Name: "password", Type: doc.String, WriteOnly: true},
+		},
+	}
+
+	a.NotError(validJSON(req, []byte(`{"name":null,"password":"123"}`)))
+
+	// 请求中包含只读字段
+	a.Error(validJSON(req, []byte(`{"id":1,"name":"n"}`)))
+
+	// 不能为 null
+	a.Error(validJSON(req, []byte(`{"password":null}`)))
+
+	// 返回内容中不包含只写字段
+	data, err := buildJSON(req)
+	a.NotError(err).Equal(string(data), `{
+    "id": 1024,
+    "name": "1024"
+}`)
+}
+
+func TestValidJSON_union(t *testing.T) {
+	a := assert.New(t)
+
+	card := &doc.Param{
+		Name: "card",
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "type", Type: doc.String},
+			{Name: "number", Type: doc.String},
+		},
+	}
+	bank := &doc.Param{
+		Name: "bank",
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "type", Type: doc.String},
+			{Name: "account", Type: doc.Number},
+		},
+	}
+	req := &doc.Request{
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "payment", OneOf: &doc.Union{Discriminator: "type", Items: []*doc.Param{card, bank}}},
+			{Name: "id", AnyOf: &doc.Union{Items: []*doc.Param{{Name: "n", Type: doc.Number}, {Name: "s", Type: doc.String}}}},
+			{Name: "name", Type: doc.String},
+		},
+	}
+
+	a.NotError(validJSON(req, []byte(`{"payment":{"type":"card","number":"1024"},"id":1,"name":"n"}`)))
+	a.NotError(validJSON(req, []byte(`{"payment":{"type":"bank","account":1024},"id":"1"}`)))
+
+	// 与 discriminator 指定的类型不匹配
+	a.Error(validJSON(req, []byte(`{"payment":{"type":"bank","number":"1024"}}`)))
+	a.Error(validJSON(req, []byte(`{"payment":{"type":"not-exists"}}`)))
+	a.Error(validJSON(req, []byte(`{"payment":{"number":"1024"}}`)))
+
+	// 不匹配任何子类型
+	a.Error(validJSON(req, []byte(`{"id":true}`)))
+
+	// 组合类型之后的字段依然可以正确验证
+	a.Error(validJSON(req, []byte(`{"payment":{"type":"card","number":"1024"},"name":5}`)))
+
+	// 同时匹配多个子类型
+	req = &doc.Request{
+		OneOf: &doc.Union{Items: []*doc.Param{{Name: "s1", Type: doc.String}, {Name: "s2", Type: doc.String}}},
+	}
+	a.Error(validJSON(req, []byte(`"1024"`)))
+	req.AnyOf, req.OneOf = req.OneOf, nil
+	a.NotError(validJSON(req, []byte(`"1024"`)))
+
+	// 生成的内容使用第一个子类型，且 discriminator 的值为子类型的名称
+	req = &doc.Request{
+		Type:  doc.Object,
+		Items: []*doc.Param{{Name: "payment", OneOf: &doc.Union{Discriminator: "type", Items: []*doc.Param{card, bank}}}},
+	}
+	data, err := buildJSON(req)
+	a.NotError(err).Equal(string(data), `{
+    "payment": {
+        "type": "card",
+        "number": "1024"
+    }
+}`)
+	a.NotError(validJSON(req, data))
+}
+
+func TestValidJSON_map(t *testing.T) {
+	a := assert.New(t)
+
+	req := &doc.Request{
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "titles", Type: doc.Map, Items: []*doc.Param{{Name: "locale", Type: doc.String}}},
+			{
+				Name: "groups",
+				Type: doc.Map,
+				Items: []*doc.Param{{
+					Name:  "group",
+					Type:  doc.Object,
+					Items: []*doc.Param{{Name: "id", Type: doc.Number}},
+				}},
+			},
+			{Name: "name", Type: doc.String},
+		},
+	}
+
+	a.NotError(validJSON(req, []byte(`{"titles":{"zh-Hans":"标题","en":"title"},"groups":{"g1":{"id":1}},"name":"n"}`)))
+	a.NotError(validJSON(req, []byte(`{"titles":{}}`)))
+
+	// 值的类型不正确
+	a.Error(validJSON(req, []byte(`{"titles":{"en":5}}`)))
+	a.Error(validJSON(req, []byte(`{"groups":{"g1":{"not-exists":1}}}`)))
+
+	// 字典之外的字段依然需要验证
+	a.Error(validJSON(req, []byte(`{"titles":{"en":"title"},"not-exists":5}`)))
+
+	data, err := buildJSON(&doc.Request{Type: doc.Map, Items: []*doc.Param{{Name: "locale", Type: doc.Number}}})
+	a.NotError(err).Equal(string(data), `{
+    "key1": 1024,
+    "key2": 1024,
+    "key3": 1024
+}`)
+}
+
 func TestBuildJSON(t *testing.T) {
 	a := assert.New(t)
 
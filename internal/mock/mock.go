@@ -70,6 +70,9 @@ func Load(h *message.Handler, path string, servers map[string]string) (http.Hand
 	if err = d.FromXML(path, 0, data); err != nil {
 		return nil, err
 	}
+	if err = d.Sanitize(); err != nil {
+		return nil, err
+	}
 
 	return New(h, d, servers)
 }
@@ -430,6 +430,7 @@ const testAPIDoc = `<apidoc version="1.0.1">
 	<server url="https://example.com" name="test" summary="test summary" />
 	<mimetype>application/json</mimetype>
 	<mimetype>application/xml</mimetype>
+	<security name="token" type="apikey" in="header" key="X-Token" />
 
 	<api method="GET" summary="get users">
 		<path path="/users" />
@@ -449,6 +450,31 @@ const testAPIDoc = `<apidoc version="1.0.1">
 			<header type="string" name="location" summary="新资源的地址" />
 		</response>
 	</api>
+	<api method="delete" summary="delete user">
+		<server>test</server>
+		<path path="/users" />
+		<security name="token" />
+		<response status="204" />
+	</api>
+	<api method="get" summary="get user">
+		<server>test</server>
+		<path path="/users/{id}">
+			<param name="id" type="integer" summary="id" />
+			<query name="tags" type="string" array="true" optional="true" summary="tags">
+				<enum value="t1" summary="t1" />
+				<enum value="t2" summary="t2" />
+			</query>
+		</path>
+		<response status="200" type="string" />
+	</api>
+	<api method="put" summary="update user">
+		<server>test</server>
+		<path path="/users" />
+		<cookie name="session" type="string" summary="session id" />
+		<response status="204">
+			<cookie name="lang" type="string" summary="language" />
+		</response>
+	</api>
 </apidoc>`
 
 func TestNew(t *testing.T) {
@@ -468,6 +494,14 @@ func TestNew(t *testing.T) {
 
 	srv.Post("/test/users", nil).Do().Status(http.StatusBadRequest)
 	srv.Get("/test/users").Do().Status(http.StatusMethodNotAllowed)
+	srv.Delete("/test/users").Do().Status(http.StatusUnauthorized)
+	// 仅缺少 cookie
+	srv.Put("/test/users", nil).
+		Header("accept", "application/json").
+		Do().
+		Status(http.StatusBadRequest)
+	srv.Get("/test/users/abc").Do().Status(http.StatusBadRequest)
+	srv.Get("/test/users/1?tags=t1&tags=t3").Do().Status(http.StatusBadRequest)
 
 	h.Stop()
 	a.NotEmpty(erro.String())
@@ -493,6 +527,24 @@ func TestNew(t *testing.T) {
 		Header("content-type", "application/json").
 		BodyEmpty()
 
+	srv.Delete("/test/users").
+		Header("accept", "application/json").
+		Header("X-Token", "xxx").
+		Do().
+		Status(http.StatusNoContent)
+
+	srv.Get("/test/users/1?tags=t1,t2&tags=t1").
+		Header("accept", "application/json").
+		Do().
+		Status(http.StatusOK)
+
+	srv.Put("/test/users", nil).
+		Header("accept", "application/json").
+		Header("cookie", "session=xxx").
+		Do().
+		Status(http.StatusNoContent).
+		Header("set-cookie", "lang=1024; Path=/")
+
 	h.Stop()
 	a.Empty(erro.String())
 
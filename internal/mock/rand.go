@@ -3,8 +3,12 @@
 package mock
 
 import (
+	"fmt"
+	"math"
 	"math/rand"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/issue9/rands"
 
@@ -13,12 +17,14 @@ import (
 
 var randOptions = &struct {
 	maxSliceSize  int
+	maxMapSize    int
 	maxNumber     int
 	maxStringSize int
 	minStringSize int
 	StringData    []byte
 }{
 	maxSliceSize:  100,
+	maxMapSize:    5,
 	maxNumber:     10000,
 	maxStringSize: 100,
 	minStringSize: 5,
@@ -27,6 +33,9 @@ var randOptions = &struct {
 
 // 当前文件提供了一些生成随机测试数据的函数
 
+// 可以转换成 int64 的最大浮点数，float64(math.MaxInt64) 已经超出了 int64 的范围。
+var maxInt64 = math.Nextafter(math.MaxInt64, 0)
+
 // 测试数据为了方便验证正确性，生成的值是固定的，
 // 而普通的 mock 数据值是随机的。通过此值判断生成哪种数据。
 //
@@ -41,25 +50,100 @@ func generateBool() bool {
 	return (rand.Int() % 2) == 0
 }
 
+// 返回 p 允许的数值范围
+func numberRange(p *doc.Param) (min, max float64) {
+	min, max = 0, float64(randOptions.maxNumber)
+
+	if p.Min != nil {
+		min = *p.Min
+		if p.Max == nil && max < min {
+			max = min + float64(randOptions.maxNumber)
+		}
+	}
+
+	if p.Max != nil {
+		max = *p.Max
+		if p.Min == nil && max < min {
+			min = max - float64(randOptions.maxNumber)
+		}
+	}
+
+	return min, max
+}
+
 func generateNumber(p *doc.Param) int64 {
 	if p.IsEnum() {
 		index := 0
 		if !test {
 			index = rand.Intn(len(p.Enums))
 		}
-		v, err := strconv.ParseInt(p.Enums[index].Value, 10, 32)
+		v, err := strconv.ParseInt(p.Enums[index].Value, 10, 64)
+		if err != nil { // 这属于文档定义错误，直接 panic
+			panic(err)
+		}
+		return v
+	}
+
+	// 超出 int64 的部分会被截断
+	min, max := numberRange(p)
+	lo := toInt64(math.Ceil(min))
+	hi := toInt64(math.Floor(max))
+
+	if test {
+		return clampInt(1024, lo, hi)
+	}
+
+	if hi <= lo {
+		return lo
+	}
+
+	// hi-lo 可能超出 int64 的范围，以无符号整数计算。
+	return lo + int64(rand.Uint64()%(uint64(hi-lo)+1))
+}
+
+func generateFloat(p *doc.Param) float64 {
+	if p.IsEnum() {
+		index := 0
+		if !test {
+			index = rand.Intn(len(p.Enums))
+		}
+		v, err := strconv.ParseFloat(p.Enums[index].Value, 64)
 		if err != nil { // 这属于文档定义错误，直接 panic
 			panic(err)
 		}
 		return v
 	}
 
+	min, max := numberRange(p)
 	if test {
-		return 1024
+		return math.Max(min, math.Min(max, 1024))
+	}
+
+	// 不采用 min+r*(max-min) 的方式，max-min 可能超出 float64 的范围。
+	r := rand.Float64()
+	return min*(1-r) + max*r
+}
+
+// 根据 p 的类型生成数值，float 为浮点数，其它的数值类型均为整数。
+//
+// number 类型的取值范围内不包含整数时，只能生成浮点数。
+func generateNumberValue(p *doc.Param) interface{} {
+	if p.Type == doc.Float {
+		return generateFloat(p)
+	}
+
+	if p.Type == doc.Number && !p.IsEnum() {
+		if min, max := numberRange(p); math.Ceil(min) > math.Floor(max) {
+			return generateFloat(p)
+		}
 	}
-	return rand.Int63n(int64(randOptions.maxNumber))
+
+	return generateNumber(p)
 }
 
+// 生成字符串
+//
+// 会根据 format 生成相应格式的字符串，pattern 无法用于生成内容，会被忽略。
 func generateString(p *doc.Param) string {
 	if p.IsEnum() {
 		index := 0
@@ -69,16 +153,166 @@ func generateString(p *doc.Param) string {
 		return p.Enums[index].Value
 	}
 
-	if test {
-		return "1024"
+	switch p.Format {
+	case doc.FormatDate:
+		if test {
+			return "2020-01-02"
+		}
+		return time.Now().Add(-time.Duration(rand.Int63n(int64(time.Hour) * 24 * 365))).Format("2006-01-02")
+	case doc.FormatDateTime:
+		if test {
+			return "2020-01-02T15:04:05Z"
+		}
+		return time.Now().Add(-time.Duration(rand.Int63n(int64(time.Hour) * 24 * 365))).Format(time.RFC3339)
+	case doc.FormatEmail:
+		if test {
+			return "1024@example.com"
+		}
+		return rands.String(randOptions.minStringSize, 20, randOptions.StringData) + "@example.com"
+	case doc.FormatURI:
+		if test {
+			return "https://example.com/1024"
+		}
+		return "https://example.com/" + rands.String(randOptions.minStringSize, 20, randOptions.StringData)
+	case doc.FormatUUID:
+		if test {
+			return "00000000-0000-4000-8000-000000001024"
+		}
+		return generateUUID()
+	}
+
+	if test { // 仅在明确指定了长度的情况下才调整长度
+		s := "1024"
+		if len(s) < p.MinLength {
+			s += strings.Repeat("0", p.MinLength-len(s))
+		}
+		if p.MaxLength > 0 && len(s) > p.MaxLength {
+			s = s[:p.MaxLength]
+		}
+		return s
 	}
-	return rands.String(randOptions.minStringSize, randOptions.maxStringSize, randOptions.StringData)
+
+	min, max := randOptions.minStringSize, randOptions.maxStringSize
+	if p.MinLength > 0 {
+		min = p.MinLength
+		if max < min {
+			max = min + randOptions.maxStringSize
+		}
+	}
+	if p.MaxLength > 0 {
+		max = p.MaxLength
+		if min > max {
+			min = max
+		}
+	}
+	return rands.String(min, max+1, randOptions.StringData)
+}
+
+// 生成版本 4 的 UUID
+func generateUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
 }
 
 // 生成随机的数组长度
-func generateSliceSize() int {
+func generateSliceSize(p *doc.Param) int {
+	min, max := p.MinItems, randOptions.maxSliceSize
+	if p.MaxItems > 0 {
+		max = p.MaxItems
+	} else if max < min {
+		max = min + randOptions.maxSliceSize
+	}
+
 	if test {
-		return 5
+		return int(clampInt(5, int64(min), int64(max)))
+	}
+	return min + rand.Intn(max-min+1)
+}
+
+// 生成字典的键名
+func generateMapKeys() []string {
+	size := 3
+	if !test {
+		size = 1 + rand.Intn(randOptions.maxMapSize)
+	}
+
+	keys := make([]string, 0, size)
+	exists := make(map[string]bool, size)
+	for len(keys) < size {
+		key := "key" + strconv.Itoa(len(keys)+1)
+		if !test {
+			key = rands.String(randOptions.minStringSize, 10, randOptions.StringData)
+		}
+
+		if !exists[key] {
+			exists[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// 将 v 转换成 int64，超出范围的值取 int64 的最大或最小值。
+func toInt64(v float64) int64 {
+	return int64(math.Max(math.MinInt64, math.Min(maxInt64, v)))
+}
+
+func clampInt(v, min, max int64) int64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// 从组合类型 p 中随机选择一个子类型
+//
+// 如果指定了 discriminator，则对应属性的值固定为子类型的名称。
+func generateUnion(p *doc.Param) *doc.Param {
+	u := p.OneOf
+	if u == nil {
+		u = p.AnyOf
+	}
+
+	index := 0
+	if !test {
+		index = rand.Intn(len(u.Items))
 	}
-	return rand.Intn(randOptions.maxSliceSize)
+	item := u.Items[index]
+	v := unionItem(p, item)
+
+	if u.Discriminator != "" {
+		v.Items = make([]*doc.Param, 0, len(item.Items))
+		for _, i := range item.Items {
+			if i.Name == u.Discriminator {
+				d := *i
+				d.Enums = []*doc.Enum{{Value: item.Name}}
+				i = &d
+			}
+			v.Items = append(v.Items, i)
+		}
+	}
+
+	return v
+}
+
+// 将组合类型 p 的子类型 item 转换成可以直接代替 p 的对象
+//
+// 子类型的名称仅用于区分各个子类型，元素名称等依然由 p 决定。
+func unionItem(p, item *doc.Param) *doc.Param {
+	v := *item
+	v.Name = p.Name
+	v.XML = p.XML
+	v.XMLWrapped = ""
+	v.Optional = p.Optional
+	v.Nullable = p.Nullable
+	v.Array = false
+	return &v
 }
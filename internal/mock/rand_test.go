@@ -2,6 +2,113 @@
 
 package mock
 
+import (
+	"math"
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+)
+
 func init() {
 	test = true
 }
+
+func TestGenerateNumber(t *testing.T) {
+	a := assert.New(t)
+
+	min := 2000.0
+	max := 3000.0
+
+	a.Equal(generateNumber(&doc.Param{Type: doc.Integer}), 1024)
+	a.Equal(generateNumber(&doc.Param{Type: doc.Integer, Constraint: doc.Constraint{Min: &min}}), 2000)
+	a.Equal(generateFloat(&doc.Param{Type: doc.Float, Constraint: doc.Constraint{Min: &min}}), 2000)
+	a.Equal(generateNumberValue(&doc.Param{Type: doc.Float}), 1024.0)
+	a.Equal(generateNumberValue(&doc.Param{Type: doc.Integer}), int64(1024))
+
+	// 范围内不包含整数
+	fmin, fmax := 0.1, 0.9
+	p := &doc.Param{Type: doc.Number, Constraint: doc.Constraint{Min: &fmin, Max: &fmax}}
+	a.Equal(generateNumberValue(p), 0.9)
+
+	wmin, wmax := -5e18, 5e18
+	hmin, hmax := -math.MaxFloat64, math.MaxFloat64
+	a.Equal(generateNumber(&doc.Param{Type: doc.Integer, Constraint: doc.Constraint{Min: &wmin, Max: &wmax}}), 1024)
+	a.Equal(generateNumber(&doc.Param{Type: doc.Integer, Constraint: doc.Constraint{Min: &hmax}}), int64(maxInt64))
+
+	test = false
+	defer func() { test = true }()
+	for i := 0; i < 100; i++ {
+		p := &doc.Param{Type: doc.Integer, Constraint: doc.Constraint{Min: &min, Max: &max}}
+		v := generateNumber(p)
+		a.True(v >= 2000 && v <= 3000)
+
+		p.Type = doc.Float
+		f := generateFloat(p)
+		a.True(f >= 2000 && f <= 3000)
+
+		p = &doc.Param{Type: doc.Number, Constraint: doc.Constraint{Min: &fmin, Max: &fmax}}
+		f, ok := generateNumberValue(p).(float64)
+		a.True(ok).True(f >= 0.1 && f <= 0.9)
+
+		// 超出 int64 的范围
+		p = &doc.Param{Type: doc.Integer, Constraint: doc.Constraint{Min: &wmin, Max: &wmax}}
+		v = generateNumber(p)
+		a.True(v >= -5e18 && v <= 5e18)
+		p = &doc.Param{Type: doc.Integer, Constraint: doc.Constraint{Min: &hmin, Max: &hmax}}
+		generateNumber(p)
+		p.Type = doc.Float
+		f = generateFloat(p)
+		a.True(f >= hmin && f <= hmax)
+	}
+}
+
+func TestGenerateString(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(generateString(&doc.Param{Type: doc.String}), "1024")
+	a.Equal(generateString(&doc.Param{Type: doc.String, Constraint: doc.Constraint{MinLength: 6}}), "102400")
+	a.Equal(generateString(&doc.Param{Type: doc.String, Constraint: doc.Constraint{MaxLength: 2}}), "10")
+
+	test = false
+	defer func() { test = true }()
+	formats := []string{doc.FormatDate, doc.FormatDateTime, doc.FormatEmail, doc.FormatURI, doc.FormatUUID}
+	for _, format := range formats {
+		p := &doc.Param{Type: doc.String, Constraint: doc.Constraint{Format: format}}
+		a.NotError(validConstraint(p, "", generateString(p)), "%s 生成的内容无法通过验证", format)
+	}
+
+	for i := 0; i < 100; i++ {
+		p := &doc.Param{Type: doc.String, Constraint: doc.Constraint{MinLength: 3, MaxLength: 5}}
+		a.NotError(validConstraint(p, "", generateString(p)))
+	}
+}
+
+func TestGenerateSliceSize(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(generateSliceSize(&doc.Param{}), 5)
+	a.Equal(generateSliceSize(&doc.Param{Constraint: doc.Constraint{MaxItems: 2}}), 2)
+	a.Equal(generateSliceSize(&doc.Param{Constraint: doc.Constraint{MinItems: 10}}), 10)
+
+	test = false
+	defer func() { test = true }()
+	for i := 0; i < 100; i++ {
+		size := generateSliceSize(&doc.Param{Constraint: doc.Constraint{MinItems: 1, MaxItems: 3}})
+		a.True(size >= 1 && size <= 3)
+	}
+}
+
+func TestGenerateMapKeys(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(generateMapKeys(), []string{"key1", "key2", "key3"})
+
+	test = false
+	defer func() { test = true }()
+	for i := 0; i < 100; i++ {
+		keys := generateMapKeys()
+		a.True(len(keys) >= 1 && len(keys) <= randOptions.maxMapSize)
+	}
+}
@@ -21,23 +21,113 @@ type xmlValidator struct {
 	param   *doc.Param
 	decoder *xml.Decoder
 	names   []string // 按顺序保存变量名称
+
+	// 按顺序保存每个元素中各个数组的元素数量
+	items []map[*doc.Param]int
 }
 
 func validXML(p *doc.Request, content []byte) error {
 	if len(content) == 0 {
-		if p == nil || p.Type == doc.None {
+		if p == nil || (p.Type == doc.None && !p.IsUnion()) {
 			return nil
 		}
 		return message.NewLocaleError("", "", 0, locale.ErrInvalidFormat)
 	}
 
+	return validXMLParam(p.Param(), "", content)
+}
+
+// 验证 content 是否符合 p 的定义
+//
+// field 表示 p 的父元素在整个对象中的位置，会作为错误信息中字段的前缀。
+func validXMLParam(p *doc.Param, field string, content []byte) error {
 	validator := &xmlValidator{
-		param:   p.Param(),
+		param:   p,
 		decoder: xml.NewDecoder(bytes.NewReader(content)),
 		names:   []string{},
+		items:   []map[*doc.Param]int{},
+	}
+
+	err := validator.valid()
+	if serr, ok := err.(*message.SyntaxError); ok && field != "" {
+		serr.Field = field + "/" + serr.Field
+	}
+	return err
+}
+
+// 验证 content 是否符合组合类型 p 中的某一个子类型
+func validXMLUnion(p *doc.Param, field string, content []byte) error {
+	u, one := p.OneOf, true
+	if u == nil {
+		u, one = p.AnyOf, false
+	}
+
+	if u.Discriminator != "" {
+		name, err := getXMLDiscriminator(content, u.Discriminator)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range u.Items {
+			if item.Name == name {
+				return validXMLParam(unionItem(p, item), field, content)
+			}
+		}
+
+		if field != "" {
+			field += "/"
+		}
+		return message.NewLocaleError("", field+p.Name+"/"+u.Discriminator, 0, locale.ErrInvalidValue)
 	}
 
-	return validator.valid()
+	matched := 0
+	for _, item := range u.Items {
+		if validXMLParam(unionItem(p, item), field, content) == nil {
+			matched++
+		}
+	}
+
+	if matched == 0 || (one && matched > 1) {
+		if field != "" {
+			field += "/"
+		}
+		return message.NewLocaleError("", field+p.Name, 0, locale.ErrInvalidValue)
+	}
+	return nil
+}
+
+// 获取 content 中名为 name 的属性或是子元素的值
+func getXMLDiscriminator(content []byte, name string) (string, error) {
+	d := xml.NewDecoder(bytes.NewReader(content))
+	deep := 0
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			return "", nil
+		} else if err != nil {
+			return "", err
+		}
+
+		switch v := token.(type) {
+		case xml.StartElement:
+			deep++
+			if deep == 1 {
+				for _, attr := range v.Attr {
+					if attr.Name.Local == name {
+						return attr.Value, nil
+					}
+				}
+			} else if deep == 2 && v.Name.Local == name {
+				var val string
+				if err := d.DecodeElement(&val, &v); err != nil {
+					return "", err
+				}
+				return val, nil
+			}
+		case xml.EndElement:
+			deep--
+		}
+	}
 }
 
 func (validator *xmlValidator) valid() error {
@@ -54,14 +144,36 @@ func (validator *xmlValidator) valid() error {
 		switch v := token.(type) {
 		case xml.StartElement:
 			validator.pushName(v.Name.Local)
+			validator.incrItems()
+			if err := validator.validReadOnly(); err != nil {
+				return err
+			}
+
+			if p := validator.find(); p != nil && p.IsUnion() {
+				if err := validator.validUnion(p, v); err != nil {
+					return err
+				}
+				if err := validator.validItems(); err != nil {
+					return err
+				}
+				validator.popName()
+				continue
+			}
+
 			for _, attr := range v.Attr {
 				validator.pushName(attr.Name.Local)
+				if err := validator.validReadOnly(); err != nil {
+					return err
+				}
 				if err := validator.validValue(attr.Value); err != nil {
 					return err
 				}
 				validator.popName()
 			}
 		case xml.EndElement:
+			if err := validator.validItems(); err != nil {
+				return err
+			}
 			validator.popName()
 		case xml.CharData:
 			if len(v) > 0 && v[0] == '\n' && (len(v[1:])%len(indent) == 0) {
@@ -76,6 +188,35 @@ func (validator *xmlValidator) valid() error {
 	}
 }
 
+// 读取以 start 开头的完整元素，并验证其是否符合组合类型 p 的定义
+func (validator *xmlValidator) validUnion(p *doc.Param, start xml.StartElement) error {
+	var elem struct {
+		Inner []byte `xml:",innerxml"`
+	}
+	if err := validator.decoder.DecodeElement(&elem, &start); err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	e := xml.NewEncoder(buf)
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.Flush(); err != nil {
+		return err
+	}
+	buf.Write(elem.Inner)
+	if err := e.EncodeToken(start.End()); err != nil {
+		return err
+	}
+	if err := e.Flush(); err != nil {
+		return err
+	}
+
+	field := strings.Join(validator.names[:len(validator.names)-1], "/")
+	return validXMLUnion(p, field, buf.Bytes())
+}
+
 // 如果 t == "" 表示不需要验证类型，比如 null 可以赋值给任何类型
 func (validator *xmlValidator) validValue(v string) error {
 	field := strings.Join(validator.names, "/")
@@ -92,7 +233,7 @@ func (validator *xmlValidator) validValue(v string) error {
 // field 表示 p 在整个对象中的位置信息。
 func validXMLParamValue(p *doc.Param, field, v string) error {
 	switch p.Type {
-	case doc.Number:
+	case doc.Number, doc.Integer, doc.Float:
 		if !is.Number(v) {
 			return message.NewLocaleError("", field, 0, locale.ErrInvalidFormat)
 		}
@@ -101,7 +242,6 @@ func validXMLParamValue(p *doc.Param, field, v string) error {
 			return message.NewLocaleError("", field, 0, locale.ErrInvalidFormat)
 		}
 	case doc.String:
-		return nil
 	case doc.None:
 		if v != "" {
 			return message.NewLocaleError("", field, 0, locale.ErrInvalidValue)
@@ -119,6 +259,41 @@ func validXMLParamValue(p *doc.Param, field, v string) error {
 		return message.NewLocaleError("", field, 0, locale.ErrInvalidValue)
 	}
 
+	return validConstraint(p, field, v)
+}
+
+// 请求中不能包含只读的字段
+func (validator *xmlValidator) validReadOnly() error {
+	if p := validator.find(); p != nil && p.ReadOnly {
+		return message.NewLocaleError("", strings.Join(validator.names, "/"), 0, locale.ErrInvalidValue)
+	}
+	return nil
+}
+
+// 如果当前元素是数组的元素，则在父元素中记录其数量，同时为当前元素添加记录。
+func (validator *xmlValidator) incrItems() {
+	if p := validator.find(); p != nil && p.Array && len(validator.items) > 0 {
+		validator.items[len(validator.items)-1][p]++
+	}
+	validator.items = append(validator.items, map[*doc.Param]int{})
+}
+
+// 验证当前元素中各个数组的元素数量，并弹出该记录。
+//
+// 未出现的数组不作检测。
+func (validator *xmlValidator) validItems() error {
+	if len(validator.items) == 0 {
+		return nil
+	}
+	items := validator.items[len(validator.items)-1]
+	validator.items = validator.items[:len(validator.items)-1]
+
+	field := strings.Join(validator.names, "/")
+	for p, size := range items {
+		if err := validItems(p, field+"/"+p.Name, size); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -161,6 +336,11 @@ LOOP:
 	for i := 0; i < len(names); i++ {
 		name := names[i]
 
+		if v := p.MapValue(); v != nil { // 字典的键名即元素名，可以是任意值
+			p = v
+			continue
+		}
+
 		for _, pp := range p.Items {
 			if pp.Array && pp.XMLWrapped == name {
 				i++
@@ -205,7 +385,7 @@ type xmlBuilder struct {
 }
 
 func buildXML(p *doc.Request) ([]byte, error) {
-	if p == nil || p.Type == doc.None {
+	if p == nil || (p.Type == doc.None && !p.IsUnion()) {
 		return nil, nil
 	}
 
@@ -251,12 +431,30 @@ func parseXML(p *doc.Param, chkArray, root bool) (*xmlBuilder, error) {
 		return builder, nil
 	}
 
+	if p.IsUnion() {
+		return parseXML(generateUnion(p), false, root)
+	}
+
+	if p.Type == doc.Map {
+		for _, key := range generateMapKeys() {
+			v := *p.MapValue()
+			v.Name = key
+			v.XMLWrapped = ""
+			b, err := parseXML(&v, false, false)
+			if err != nil {
+				return nil, err
+			}
+			builder.items = append(builder.items, b)
+		}
+		return builder, nil
+	}
+
 	if p.Type != doc.Object {
 		switch p.Type {
 		case doc.Bool:
 			builder.charData = fmt.Sprint(generateBool())
-		case doc.Number:
-			builder.charData = fmt.Sprint(generateNumber(p))
+		case doc.Number, doc.Integer, doc.Float:
+			builder.charData = fmt.Sprint(generateNumberValue(p))
 		case doc.String:
 			builder.charData = fmt.Sprint(generateString(p))
 		}
@@ -265,6 +463,7 @@ func parseXML(p *doc.Param, chkArray, root bool) (*xmlBuilder, error) {
 
 	for _, item := range p.Items {
 		switch {
+		case item.WriteOnly: // 返回内容中不包含只写的字段
 		case item.XMLAttr:
 			v, err := getXMLValue(item)
 			if err != nil {
@@ -316,7 +515,7 @@ func parseArray(p *doc.Param, parent *xmlBuilder) error {
 		parent.items = append(parent.items, b)
 	}
 
-	size := generateSliceSize()
+	size := generateSliceSize(p)
 	for i := 0; i < size; i++ {
 		bb, err := parseXML(p, false, false)
 		if err != nil {
@@ -350,13 +549,17 @@ func (builder *xmlBuilder) encode(e *xml.Encoder) error {
 }
 
 func getXMLValue(p *doc.Param) (interface{}, error) {
+	if p.IsUnion() {
+		return getXMLValue(generateUnion(p))
+	}
+
 	switch p.Type {
 	case doc.None:
 		return "", nil
 	case doc.Bool:
 		return generateBool(), nil
-	case doc.Number:
-		return generateNumber(p), nil
+	case doc.Number, doc.Integer, doc.Float:
+		return generateNumberValue(p), nil
 	case doc.String:
 		return generateString(p), nil
 	default: // doc.Object:
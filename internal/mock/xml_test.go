@@ -40,6 +40,126 @@ func TestValidXML(t *testing.T) {
 	a.Error(validXML(p, []byte(content)))
 }
 
+func TestValidXML_constraint(t *testing.T) {
+	a := assert.New(t)
+
+	max := 10.0
+	req := &doc.Request{
+		Name: "root",
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "id", Type: doc.Integer, Constraint: doc.Constraint{Max: &max}, XML: doc.XML{XMLAttr: true}},
+			{
+				Name:       "tag",
+				Type:       doc.String,
+				Array:      true,
+				Constraint: doc.Constraint{MinItems: 1, MaxItems: 2},
+				XML:        doc.XML{XMLWrapped: "tags"},
+			},
+		},
+	}
+
+	a.NotError(validXML(req, []byte(`<root id="5"><tags><tag>t1</tag><tag>t2</tag></tags></root>`)))
+	a.Error(validXML(req, []byte(`<root id="5.5"><tags><tag>t1</tag></tags></root>`)))
+	a.Error(validXML(req, []byte(`<root id="11"><tags><tag>t1</tag></tags></root>`)))
+	a.Error(validXML(req, []byte(`<root id="5"><tags><tag>t1</tag><tag>t2</tag><tag>t3</tag></tags></root>`)))
+}
+
+func TestValidXML_modifier(t *testing.T) {
+	a := assert.New(t)
+
+	req := &doc.Request{
+		Name: "root",
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "id", Type: doc.Number, ReadOnly: true, XML: doc.XML{XMLAttr: true}},
+			{Name: "name", Type: doc.String, ReadOnly: true},
+			{Name: "password", Type: doc.String, WriteOnly: true},
+		},
+	}
+
+	a.NotError(validXML(req, []byte(`<root><password>123</password></root>`)))
+
+	// 请求中包含只读字段
+	a.Error(validXML(req, []byte(`<root id="1"></root>`)))
+	a.Error(validXML(req, []byte(`<root><name>n</name></root>`)))
+
+	// 返回内容中不包含只写字段
+	data, err := buildXML(req)
+	a.NotError(err).Equal(string(data), `<root id="1024">
+    <name>1024</name>
+</root>`)
+}
+
+func TestValidXML_union(t *testing.T) {
+	a := assert.New(t)
+
+	card := &doc.Param{
+		Name: "card",
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "type", Type: doc.String, XML: doc.XML{XMLAttr: true}},
+			{Name: "number", Type: doc.String},
+		},
+	}
+	bank := &doc.Param{
+		Name: "bank",
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "type", Type: doc.String, XML: doc.XML{XMLAttr: true}},
+			{Name: "account", Type: doc.Number},
+		},
+	}
+	req := &doc.Request{
+		Name: "root",
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "payment", OneOf: &doc.Union{Discriminator: "type", Items: []*doc.Param{card, bank}}},
+			{Name: "id", AnyOf: &doc.Union{Items: []*doc.Param{{Name: "n", Type: doc.Number}, {Name: "b", Type: doc.Bool}}}},
+		},
+	}
+
+	a.NotError(validXML(req, []byte(`<root><payment type="card"><number>1024</number></payment><id>1</id></root>`)))
+	a.NotError(validXML(req, []byte(`<root><payment type="bank"><account>1024</account></payment><id>true</id></root>`)))
+
+	// 与 discriminator 指定的类型不匹配
+	a.Error(validXML(req, []byte(`<root><payment type="bank"><number>1024</number></payment></root>`)))
+	a.Error(validXML(req, []byte(`<root><payment type="not-exists"></payment></root>`)))
+
+	// 不匹配任何子类型
+	a.Error(validXML(req, []byte(`<root><id>abc</id></root>`)))
+
+	// 生成的内容使用第一个子类型，且 discriminator 的值为子类型的名称
+	req.Items = req.Items[:1]
+	data, err := buildXML(req)
+	a.NotError(err).Equal(string(data), `<root>
+    <payment type="card">
+        <number>1024</number>
+    </payment>
+</root>`)
+	a.NotError(validXML(req, data))
+}
+
+func TestValidXML_map(t *testing.T) {
+	a := assert.New(t)
+
+	req := &doc.Request{
+		Name:  "titles",
+		Type:  doc.Map,
+		Items: []*doc.Param{{Name: "locale", Type: doc.Number}},
+	}
+
+	a.NotError(validXML(req, []byte(`<titles><en>1</en><zh>2</zh></titles>`)))
+	a.Error(validXML(req, []byte(`<titles><en>title</en></titles>`)))
+
+	data, err := buildXML(req)
+	a.NotError(err).Equal(string(data), `<titles>
+    <key1>1024</key1>
+    <key2>1024</key2>
+    <key3>1024</key3>
+</titles>`)
+}
+
 func TestBuildXML(t *testing.T) {
 	a := assert.New(t)
 
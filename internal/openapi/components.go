@@ -0,0 +1,295 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/caixw/apidoc/v6/doc"
+)
+
+// 引用 Components.Responses 中对象的前缀
+const responseRefPrefix = "#/components/responses/"
+
+// 生成 openapi.Components 的内容
+//
+// doc.Types 转换成 Components.Schemas，doc.Security 转换成 Components.SecuritySchemes，
+// doc.Responses 转换成 Components.Responses，并被所有未定义该状态码的 operation 引用。
+// 同时会将结构完全相同的对象提取到 Components.Schemas 中，以 $ref 的形式引用。
+func parseComponents(openapi *OpenAPI, d *doc.Doc) {
+	c := &Components{
+		Schemas:   make(map[string]*Schema, len(d.Types)),
+		Responses: make(map[string]*Response, len(d.Responses)),
+	}
+
+	if len(d.Security) > 0 {
+		c.SecuritySchemes = make(map[string]*SecurityScheme, len(d.Security))
+		for _, s := range d.Security {
+			c.SecuritySchemes[s.Name] = newSecurityScheme(s)
+		}
+	}
+
+	for _, t := range d.Types {
+		// 引用方在解析时已经从类型中继承了 array 属性，
+		// 所以这里只生成元素的类型，否则会变成数组的数组。
+		elem := *t
+		elem.Array = false
+		c.Schemas[t.Name] = newSchema(&elem, false)
+	}
+
+	setResponses(c.Responses, d.Responses)
+	for _, path := range openapi.Paths {
+		for _, o := range operations(path) {
+			for status := range c.Responses {
+				if _, found := o.Responses[status]; !found {
+					o.Responses[status] = &Response{Ref: responseRefPrefix + status}
+				}
+			}
+		}
+	}
+
+	newSchemaWalker(c.Schemas).walk(openapi, c)
+
+	if len(c.Schemas) > 0 || len(c.Responses) > 0 || len(c.SecuritySchemes) > 0 {
+		openapi.Components = c
+	}
+}
+
+// 按固定的顺序返回 path 中的所有 Operation
+func operations(path *PathItem) []*Operation {
+	ops := make([]*Operation, 0, 8)
+	for _, o := range []*Operation{path.Get, path.Put, path.Post, path.Delete, path.Options, path.Head, path.Patch, path.Trace} {
+		if o != nil {
+			ops = append(ops, o)
+		}
+	}
+	return ops
+}
+
+// 用于查找结构相同的对象，并将其提取到 Components.Schemas 中。
+//
+// 仅处理包含 Properties 的对象类型，标题、描述等不影响结构的字段不参与比较。
+// 先遍历一次统计每种结构出现的次数，第二次遍历时将出现多次的对象替换成 $ref。
+// 所有的遍历都按固定的顺序进行，保证每次生成的名称都是相同的。
+type schemaWalker struct {
+	schemas map[string]*Schema
+	counts  map[string]int
+	names   map[string]string // 结构对应的 Components.Schemas 中的名称
+}
+
+func newSchemaWalker(schemas map[string]*Schema) *schemaWalker {
+	w := &schemaWalker{
+		schemas: schemas,
+		counts:  make(map[string]int, 10),
+		names:   make(map[string]string, len(schemas)),
+	}
+
+	// 与 doc.Types 中定义的结构相同，直接引用该类型。
+	for _, name := range sortedKeys(schemas) {
+		s := schemas[name]
+		if len(s.Properties) == 0 {
+			continue
+		}
+
+		key := schemaKey(s)
+		if _, found := w.names[key]; !found {
+			w.names[key] = name
+		}
+	}
+
+	return w
+}
+
+func (w *schemaWalker) walk(openapi *OpenAPI, c *Components) {
+	w.each(openapi, c, func(s *Schema) *Schema {
+		w.count(s)
+		return s
+	})
+
+	w.each(openapi, c, w.replace)
+}
+
+// 对 openapi 和 c 中的所有顶层 Schema 调用 f，并以 f 的返回值替换原来的值。
+//
+// c.Schemas 中的对象本身不会被替换，只处理其子元素。
+func (w *schemaWalker) each(openapi *OpenAPI, c *Components, f func(*Schema) *Schema) {
+	for _, name := range sortedKeys(c.Schemas) {
+		w.children(c.Schemas[name], f)
+	}
+
+	for _, status := range sortedResponseKeys(c.Responses) {
+		w.response(c.Responses[status], f)
+	}
+
+	paths := make([]string, 0, len(openapi.Paths))
+	for p := range openapi.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		for _, o := range operations(openapi.Paths[p]) {
+			for _, param := range o.Parameters {
+				if param.Schema != nil {
+					param.Schema = f(param.Schema)
+				}
+			}
+
+			if o.RequestBody != nil {
+				w.content(o.RequestBody.Content, f)
+			}
+
+			for _, status := range sortedResponseKeys(o.Responses) {
+				w.response(o.Responses[status], f)
+			}
+		}
+	}
+}
+
+func (w *schemaWalker) response(resp *Response, f func(*Schema) *Schema) {
+	names := make([]string, 0, len(resp.Headers))
+	for name := range resp.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if h := resp.Headers[name]; h.Schema != nil {
+			h.Schema = f(h.Schema)
+		}
+	}
+
+	w.content(resp.Content, f)
+}
+
+func (w *schemaWalker) content(content map[string]*MediaType, f func(*Schema) *Schema) {
+	keys := make([]string, 0, len(content))
+	for key := range content {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if mt := content[key]; mt.Schema != nil {
+			mt.Schema = f(mt.Schema)
+		}
+	}
+}
+
+func (w *schemaWalker) children(s *Schema, f func(*Schema) *Schema) {
+	if s.Items != nil {
+		s.Items = f(s.Items)
+	}
+
+	for _, name := range sortedKeys(s.Properties) {
+		s.Properties[name] = f(s.Properties[name])
+	}
+
+	if s.AdditionalProperties != nil {
+		s.AdditionalProperties = f(s.AdditionalProperties)
+	}
+
+	for i, item := range s.OneOf {
+		s.OneOf[i] = f(item)
+	}
+
+	for i, item := range s.AnyOf {
+		s.AnyOf[i] = f(item)
+	}
+}
+
+func (w *schemaWalker) count(s *Schema) {
+	if s.Ref != "" {
+		return
+	}
+
+	if len(s.Properties) > 0 {
+		w.counts[schemaKey(s)]++
+	}
+
+	w.children(s, func(child *Schema) *Schema {
+		w.count(child)
+		return child
+	})
+}
+
+func (w *schemaWalker) replace(s *Schema) *Schema {
+	if s.Ref != "" {
+		return s
+	}
+
+	if len(s.Properties) > 0 {
+		key := schemaKey(s)
+		if name, found := w.names[key]; found {
+			return &Schema{Ref: schemaRefPrefix + name}
+		}
+
+		if w.counts[key] > 1 {
+			name := w.newName(s)
+			c := cleanSchema(s)
+			w.names[key] = name
+			w.schemas[name] = c
+			w.children(c, w.replace)
+			return &Schema{Ref: schemaRefPrefix + name}
+		}
+	}
+
+	w.children(s, w.replace)
+	return s
+}
+
+// 根据 s 生成一个在 Components.Schemas 中唯一的名称
+func (w *schemaWalker) newName(s *Schema) string {
+	base := "schema"
+	if s.XML != nil && s.XML.Name != "" {
+		base = s.XML.Name
+	}
+
+	name := base
+	for i := 1; ; i++ {
+		if _, found := w.schemas[name]; !found {
+			return name
+		}
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// 去掉 s 中不影响结构的字段，返回新的对象
+func cleanSchema(s *Schema) *Schema {
+	c := *s
+	c.Title = ""
+	c.Description = ""
+	c.Default = nil
+	c.Deprecated = false
+	c.XML = nil
+	c.Example = ""
+	return &c
+}
+
+func schemaKey(s *Schema) string {
+	data, err := json.Marshal(cleanSchema(s))
+	if err != nil { // Schema 中都是可序列化的类型，不会出错。
+		panic(err)
+	}
+	return string(data)
+}
+
+func sortedKeys(schemas map[string]*Schema) []string {
+	keys := make([]string, 0, len(schemas))
+	for key := range schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedResponseKeys(responses map[string]*Response) []string {
+	keys := make([]string, 0, len(responses))
+	for key := range responses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
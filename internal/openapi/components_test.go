@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/doc/doctest"
+)
+
+const componentsDoc = `<apidoc version="1.1.1">
+	<title>title</title>
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<mimetype>application/json</mimetype>
+
+	<type name="user" type="object" summary="user">
+		<param name="id" type="number" summary="id" />
+		<param name="name" type="string" summary="name" />
+	</type>
+	<type name="users" ref="user" array="true" />
+
+	<response status="500" mimetype="application/json" type="object" summary="error">
+		<param name="code" type="number" summary="code" />
+		<param name="message" type="string" summary="message" />
+	</response>
+
+	<api method="GET" summary="get users">
+		<path path="/users" />
+		<server>admin</server>
+		<response status="200" mimetype="application/json" ref="users" />
+	</api>
+
+	<api method="POST" summary="post users">
+		<path path="/users" />
+		<server>admin</server>
+		<request type="object" mimetype="application/json">
+			<param name="id" type="number" summary="id" />
+			<param name="name" type="string" summary="name" />
+		</request>
+		<response status="201" mimetype="application/json" type="object" summary="created">
+			<param name="location" type="object" summary="location">
+				<param name="x" type="number" summary="x" />
+				<param name="y" type="number" summary="y" />
+			</param>
+		</response>
+		<response status="500" mimetype="application/json" type="object" summary="error">
+			<param name="code" type="number" summary="code" />
+		</response>
+	</api>
+
+	<api method="PUT" summary="put users">
+		<path path="/users" />
+		<server>admin</server>
+		<response status="200" mimetype="application/json" type="object" summary="ok">
+			<param name="location" type="object" summary="another location">
+				<param name="x" type="number" summary="x" />
+				<param name="y" type="number" summary="y" />
+			</param>
+		</response>
+	</api>
+</apidoc>`
+
+func TestParseComponents(t *testing.T) {
+	a := assert.New(t)
+
+	d := doc.New()
+	a.NotError(d.FromXML("doc.xml", 1, []byte(componentsDoc)))
+	a.NotError(d.Sanitize())
+
+	openapi, err := convert(d)
+	a.NotError(err).NotNil(openapi.Components)
+	c := openapi.Components
+
+	// doc.Types
+	a.Equal(3, len(c.Schemas))
+	user := c.Schemas["user"]
+	a.NotNil(user).Equal(2, len(user.Properties))
+	a.Equal(c.Schemas["users"].Ref, schemaRefPrefix+"user")
+
+	// 结构相同的对象
+	location := c.Schemas["location"]
+	a.NotNil(location).Equal(2, len(location.Properties)).Empty(location.Title)
+
+	// doc.Responses
+	a.Equal(1, len(c.Responses))
+	a.Equal(2, len(c.Responses["500"].Content["application/json"].Schema.Properties))
+
+	path := openapi.Paths["/users"]
+	get := path.Get.Responses
+	a.Equal(2, len(get))
+	a.Equal(get["500"].Ref, responseRefPrefix+"500")
+	s := get["200"].Content["application/json"].Schema
+	a.Equal(s.Type, TypeArray).Equal(s.Items.Ref, schemaRefPrefix+"users")
+
+	// 与 user 类型的结构相同
+	s = path.Post.RequestBody.Content["application/json"].Schema
+	a.Equal(s.Ref, schemaRefPrefix+"user")
+
+	// 自定义了 500，不会被替换成引用
+	post := path.Post.Responses
+	a.Empty(post["500"].Ref).Equal(1, len(post["500"].Content["application/json"].Schema.Properties))
+	s = post["201"].Content["application/json"].Schema
+	a.Equal(s.Properties["location"].Ref, schemaRefPrefix+"location")
+
+	s = path.Put.Responses["200"].Content["application/json"].Schema
+	a.Equal(s.Properties["location"].Ref, schemaRefPrefix+"location")
+
+	// 没有可复用的内容
+	openapi, err = convert(doctest.Get())
+	a.NotError(err).Nil(openapi.Components)
+}
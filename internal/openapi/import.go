@@ -0,0 +1,831 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/issue9/is"
+	"github.com/issue9/version"
+	xmessage "golang.org/x/text/message"
+	"gopkg.in/yaml.v2"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/message"
+)
+
+// 相对地址的服务，在转换成 doc.Server 时需要加上的前缀
+//
+// doc.Server 的地址不能为 localhost 这类不带顶级域名的主机名，所以采用 IP 地址。
+const localhost = "http://127.0.0.1"
+
+// 未指定任何 mimetype 时采用的默认值
+const defaultMimetype = "application/json"
+
+// 引用链的最大长度，超过此值被当作循环引用
+const maxRefDepth = 10
+
+var pathParamRE = regexp.MustCompile(`{([^}]+)}`)
+
+// 将 OpenAPI 转换成 doc.Doc
+//
+// 无法在 doc.Doc 中表示的内容会被忽略，同时在 warns 中记录其位置。
+type importer struct {
+	oa         *OpenAPI
+	components *Components
+	doc        *doc.Doc
+	warns      []*message.SyntaxError
+
+	types     map[string]*doc.Param // 已经转换的 Components.Schemas，值为 nil 表示转换失败。
+	resolving map[string]bool       // 正在转换中的 Components.Schemas，用于检测循环引用。
+	servers   map[string]string     // 服务的地址与名称的对应关系
+
+	// 顶层的服务名称，未指定服务的 API 会使用这些服务。
+	globalServers []string
+
+	tags      map[string]bool
+	mimetypes map[string]bool
+}
+
+// Unmarshal 从 JSON 或是 YAML 格式的 data 中解析 OpenAPI 对象
+//
+// 仅支持 3.x 版本的 OpenAPI。
+func Unmarshal(data []byte) (*OpenAPI, error) {
+	oa := &OpenAPI{}
+	if err := yaml.Unmarshal(data, oa); err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(oa.OpenAPI, "3.") {
+		return nil, message.NewLocaleError("", "openapi", 0, locale.ErrInvalidValue)
+	}
+
+	if oa.Info == nil {
+		return nil, message.NewLocaleError("", "info", 0, locale.ErrRequired)
+	}
+
+	if oa.Info.Title == "" {
+		return nil, message.NewLocaleError("", "info.title", 0, locale.ErrRequired)
+	}
+
+	return oa, nil
+}
+
+// Import 将 oa 转换成 doc.Doc
+//
+// 返回的 warns 为无法转换的内容，这些内容会被忽略，但不影响其它内容的转换。
+// Components.Schemas 会被转换成 doc.Types，$ref 引用的其它对象则直接展开。
+func Import(oa *OpenAPI) (d *doc.Doc, warns []*message.SyntaxError) {
+	i := &importer{
+		oa:         oa,
+		components: oa.Components,
+		doc:        &doc.Doc{Title: oa.Info.Title},
+		types:      make(map[string]*doc.Param, 10),
+		resolving:  make(map[string]bool, 10),
+		servers:    make(map[string]string, len(oa.Servers)),
+		tags:       make(map[string]bool, len(oa.Tags)),
+		mimetypes:  make(map[string]bool, 5),
+	}
+	if i.components == nil {
+		i.components = &Components{}
+	}
+
+	i.info(oa.Info)
+
+	if len(oa.Servers) == 0 { // 与 OpenAPI 相同，没有服务时采用 / 作为默认值。
+		i.globalServers = []string{i.addServer(localhost+"/", "")}
+	}
+	for index, srv := range oa.Servers {
+		i.globalServers = append(i.globalServers, i.server(srv, "servers["+strconv.Itoa(index)+"]"))
+	}
+
+	for index, tag := range oa.Tags {
+		i.tag(tag, "tags["+strconv.Itoa(index)+"]")
+	}
+
+	if oa.ExternalDocs != nil {
+		i.unsupported("externalDocs")
+	}
+
+	for _, name := range sortedKeys(i.components.Schemas) {
+		i.typ(name, "components.schemas["+name+"]")
+	}
+	sort.SliceStable(i.doc.Types, func(m, n int) bool {
+		return i.doc.Types[m].Name < i.doc.Types[n].Name
+	})
+
+	names := make([]string, 0, len(i.components.SecuritySchemes))
+	for name := range i.components.SecuritySchemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		i.security(name, i.components.SecuritySchemes[name], "components.securitySchemes["+name+"]")
+	}
+
+	paths := make([]string, 0, len(oa.Paths))
+	for path := range oa.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		i.path(path, oa.Paths[path], "paths["+path+"]")
+	}
+
+	if len(i.doc.Mimetypes) == 0 {
+		i.doc.Mimetypes = []string{defaultMimetype}
+	}
+
+	return i.doc, i.warns
+}
+
+func (i *importer) warn(field string, key xmessage.Reference, v ...interface{}) {
+	i.warns = append(i.warns, message.NewLocaleError("", field, 0, key, v...))
+}
+
+// 记录无法转换的内容
+func (i *importer) unsupported(field string) {
+	i.warn(field, locale.ImportUnsupported)
+}
+
+func (i *importer) info(info *Info) {
+	i.doc.Version = i.version(info.Version)
+	if desc := strings.TrimSpace(info.Description); desc != "" {
+		i.doc.Description = doc.Richtext{Type: doc.RichtextTypeMarkdown, Text: desc}
+	}
+
+	if info.TermsOfService != "" {
+		i.unsupported("info.termsOfService")
+	}
+
+	if c := info.Contact; c != nil {
+		name := c.Name
+		if name == "" {
+			name = c.Email
+		}
+		if name == "" {
+			name = c.URL
+		}
+
+		if name == "" {
+			i.unsupported("info.contact")
+		} else {
+			i.doc.Contact = &doc.Contact{Name: name, URL: c.URL, Email: c.Email}
+		}
+	}
+
+	if l := info.License; l != nil {
+		if is.URL(l.URL) {
+			i.doc.License = &doc.Link{Text: l.Name, URL: l.URL}
+		} else {
+			i.unsupported("info.license")
+		}
+	}
+}
+
+// 转换版本号，缺少的次版本号和修订号以 0 补全，比如 v1.2 会被转换成 1.2.0。
+func (i *importer) version(v string) doc.Version {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if version.SemVerValid(v) {
+		return doc.Version(v)
+	}
+
+	if parts := strings.Split(v, "."); len(parts) < 3 {
+		for len(parts) < 3 {
+			parts = append(parts, "0")
+		}
+		if vv := strings.Join(parts, "."); version.SemVerValid(vv) {
+			return doc.Version(vv)
+		}
+	}
+
+	i.unsupported("info.version")
+	return ""
+}
+
+// 废弃的版本号
+//
+// OpenAPI 的 deprecated 仅表示已经废弃，不包含具体的版本号，
+// 所以统一采用文档的版本号，即在当前版本已经被废弃。
+func (i *importer) deprecated() doc.Version {
+	if i.doc.Version != "" {
+		return i.doc.Version
+	}
+	return "1.0.0"
+}
+
+// 转换服务并返回其在 doc.Doc 中的名称
+//
+// 地址中的变量会以其默认值代替，相对地址会加上 http://127.0.0.1 前缀。
+// 地址相同的服务只会添加一次。
+func (i *importer) server(srv *Server, field string) string {
+	url := srv.URL
+	if len(srv.Variables) > 0 {
+		i.unsupported(field + ".variables")
+		for name, v := range srv.Variables {
+			url = strings.Replace(url, "{"+name+"}", v.Default, -1)
+		}
+	}
+
+	if !is.URL(url) {
+		i.unsupported(field + ".url")
+		url = localhost + "/" + strings.TrimPrefix(url, "/")
+	}
+
+	return i.addServer(url, srv.Description)
+}
+
+func (i *importer) addServer(url, desc string) string {
+	if name, found := i.servers[url]; found {
+		return name
+	}
+
+	s := &doc.Server{
+		Name: "server" + strconv.Itoa(len(i.doc.Servers)+1),
+		URL:  url,
+	}
+	s.Summary, s.Description = newSummary("", desc)
+	if s.Summary == "" && s.Description.Text == "" {
+		s.Summary = url
+	}
+
+	i.servers[url] = s.Name
+	i.doc.Servers = append(i.doc.Servers, s)
+	return s.Name
+}
+
+func (i *importer) tag(tag *Tag, field string) {
+	if tag.Name == "" {
+		i.unsupported(field)
+		return
+	}
+
+	if tag.ExternalDocs != nil {
+		i.unsupported(field + ".externalDocs")
+	}
+
+	title := tag.Name
+	if summary, desc := newSummary("", tag.Description); summary != "" {
+		title = summary
+	} else if desc.Text != "" { // doc.Tag 只能包含单行的标题
+		i.unsupported(field + ".description")
+	}
+
+	i.addTag(tag.Name, title)
+}
+
+func (i *importer) addTag(name, title string) {
+	if i.tags[name] {
+		return
+	}
+	i.tags[name] = true
+	i.doc.Tags = append(i.doc.Tags, &doc.Tag{Name: name, Title: title})
+}
+
+func (i *importer) addMimetype(mimetype string) {
+	if i.mimetypes[mimetype] {
+		return
+	}
+	i.mimetypes[mimetype] = true
+	i.doc.Mimetypes = append(i.doc.Mimetypes, mimetype)
+}
+
+func (i *importer) security(name string, s *SecurityScheme, field string) {
+	if s.Ref != "" {
+		i.unsupported(field + ".$ref")
+		return
+	}
+
+	sec := &doc.Security{Name: name}
+	sec.Summary, sec.Description = newSummary("", s.Description)
+
+	switch s.Type {
+	case SecurityTypeAPIKey:
+		sec.Type = doc.SecurityAPIKey
+		sec.IN = s.IN
+		sec.Key = s.Name
+	case SecurityTypeHTTP:
+		sec.Type = doc.SecurityHTTP
+		sec.Scheme = s.Scheme
+		sec.BearerFormat = s.BearerFormat
+	case SecurityTypeOAuth2:
+		sec.Type = doc.SecurityOAuth2
+		if s.Flows == nil {
+			i.unsupported(field + ".flows")
+			return
+		}
+		sec.Flows = appendFlow(sec.Flows, doc.FlowImplicit, s.Flows.Implicit)
+		sec.Flows = appendFlow(sec.Flows, doc.FlowPassword, s.Flows.Password)
+		sec.Flows = appendFlow(sec.Flows, doc.FlowClientCredentials, s.Flows.ClientCredentials)
+		sec.Flows = appendFlow(sec.Flows, doc.FlowAuthorizationCode, s.Flows.AuthorizationCode)
+	case SecurityTypeOpenIDConnect:
+		sec.Type = doc.SecurityOpenIDConnect
+		sec.URL = s.OpenIDConnectURL
+	default:
+		i.unsupported(field + ".type")
+		return
+	}
+
+	i.doc.Security = append(i.doc.Security, sec)
+}
+
+func appendFlow(flows []*doc.OAuthFlow, typ string, flow *OAuthFlow) []*doc.OAuthFlow {
+	if flow == nil {
+		return flows
+	}
+
+	f := &doc.OAuthFlow{
+		Type:             typ,
+		AuthorizationURL: flow.AuthorizationURL,
+		TokenURL:         flow.TokenURL,
+		RefreshURL:       flow.RefreshURL,
+		Scopes:           make([]*doc.Scope, 0, len(flow.Scopes)),
+	}
+
+	names := make([]string, 0, len(flow.Scopes))
+	for name := range flow.Scopes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		summary := flow.Scopes[name]
+		if summary == "" {
+			summary = name
+		}
+		f.Scopes = append(f.Scopes, &doc.Scope{Name: name, Summary: summary})
+	}
+
+	return append(flows, f)
+}
+
+func (i *importer) path(path string, item *PathItem, field string) {
+	if item.Ref != "" {
+		i.unsupported(field + ".$ref")
+		return
+	}
+
+	ops := []struct {
+		method string
+		op     *Operation
+	}{
+		{http.MethodGet, item.Get},
+		{http.MethodPut, item.Put},
+		{http.MethodPost, item.Post},
+		{http.MethodDelete, item.Delete},
+		{http.MethodOptions, item.Options},
+		{http.MethodHead, item.Head},
+		{http.MethodPatch, item.Patch},
+		{http.MethodTrace, item.Trace},
+	}
+	for _, o := range ops {
+		if o.op == nil {
+			continue
+		}
+
+		f := field + "." + strings.ToLower(o.method)
+		if o.method == http.MethodTrace { // doc.API 不支持 TRACE
+			i.unsupported(f)
+			continue
+		}
+		i.api(path, o.method, item, o.op, field, f)
+	}
+}
+
+// pathField 和 field 分别为 item 和 op 在文档中的位置
+func (i *importer) api(path, method string, item *PathItem, op *Operation, pathField, field string) {
+	api := &doc.API{
+		Method: doc.Method(method),
+		ID:     op.OperationID,
+		Path:   &doc.Path{Path: path},
+		Tags:   op.Tags,
+	}
+	api.Summary, api.Description = newSummary(op.Summary, op.Description)
+	if op.Deprecated {
+		api.Deprecated = i.deprecated()
+	}
+
+	for _, tag := range op.Tags {
+		i.addTag(tag, tag)
+	}
+
+	if op.ExternalDocs != nil {
+		i.unsupported(field + ".externalDocs")
+	}
+
+	i.params(api, item, op, pathField, field)
+
+	// servers
+	switch {
+	case len(op.Servers) > 0:
+		for index, srv := range op.Servers {
+			api.Servers = append(api.Servers, i.server(srv, field+".servers["+strconv.Itoa(index)+"]"))
+		}
+	case len(item.Servers) > 0:
+		for index, srv := range item.Servers {
+			api.Servers = append(api.Servers, i.server(srv, field+".servers["+strconv.Itoa(index)+"]"))
+		}
+	default:
+		api.Servers = i.globalServers
+	}
+
+	// security
+	requirements, f := op.Security, field+".security"
+	if requirements == nil {
+		requirements, f = i.oa.Security, "security"
+	}
+	for index, req := range requirements {
+		i.securityRequirement(api, req, f+"["+strconv.Itoa(index)+"]")
+	}
+
+	api.Requests = i.requestBody(op.RequestBody, field+".requestBody")
+	api.Responses = i.responses(op.Responses, field+".responses")
+
+	if len(op.Callbacks) > 0 {
+		i.unsupported(field + ".callbacks")
+	}
+
+	i.doc.Apis = append(i.doc.Apis, api)
+}
+
+// 将 PathItem 和 Operation 中的参数写入 api，Operation 中的参数会覆盖 PathItem 中的同名参数。
+//
+// 路径中未声明的参数，会以字符串类型的参数添加到 api.Path.Params 中。
+func (i *importer) params(api *doc.API, item *PathItem, op *Operation, pathField, field string) {
+	type param struct {
+		p     *Parameter
+		field string
+	}
+	params := make([]*param, 0, len(item.Parameters)+len(op.Parameters))
+	indexes := make(map[string]int, cap(params))
+
+	add := func(parameters []*Parameter, field string) {
+		for index, p := range parameters {
+			f := field + ".parameters[" + strconv.Itoa(index) + "]"
+			if p = i.parameter(p, f); p == nil {
+				continue
+			}
+
+			key := p.IN + ":" + p.Name
+			if index, found := indexes[key]; found {
+				params[index] = &param{p: p, field: f}
+				continue
+			}
+			indexes[key] = len(params)
+			params = append(params, &param{p: p, field: f})
+		}
+	}
+	add(item.Parameters, pathField)
+	add(op.Parameters, field)
+
+	for _, item := range params {
+		p := i.newParam(item.p.Name, item.p.Description, item.p.Required, item.p.Deprecated, item.p.Schema, item.p.Content, item.field)
+		if p == nil {
+			continue
+		}
+
+		if item.p.Example != "" || len(item.p.Examples) > 0 {
+			i.unsupported(item.field + ".examples")
+		}
+
+		switch item.p.IN {
+		case ParameterINPath:
+			p.Optional = false
+			api.Path.Params = append(api.Path.Params, p)
+		case ParameterINQuery:
+			api.Path.Queries = append(api.Path.Queries, p)
+		case ParameterINHeader:
+			api.Headers = append(api.Headers, p)
+		case ParameterINCookie:
+			if p.Array || p.IsUnion() || p.Type == doc.Object || p.Type == doc.Map {
+				i.unsupported(item.field)
+				continue
+			}
+			api.Cookies = append(api.Cookies, p)
+		default:
+			i.unsupported(item.field + ".in")
+		}
+	}
+
+LOOP:
+	for _, m := range pathParamRE.FindAllStringSubmatch(api.Path.Path, -1) {
+		for _, p := range api.Path.Params {
+			if p.Name == m[1] {
+				continue LOOP
+			}
+		}
+		api.Path.Params = append(api.Path.Params, &doc.Param{Name: m[1], Type: doc.String, Summary: m[1]})
+	}
+}
+
+// 生成参数或是报头对应的 doc.Param
+func (i *importer) newParam(name, desc string, required, deprecated bool, s *Schema, content map[string]*MediaType, field string) *doc.Param {
+	if s == nil && len(content) > 0 {
+		i.unsupported(field + ".content")
+		s = content[sortedContentKeys(content)[0]].Schema
+	}
+
+	var p *doc.Param
+	if s != nil {
+		p = i.param(name, s, field+".schema")
+	} else {
+		p = &doc.Param{Name: name, Type: doc.String, Summary: name}
+	}
+	if p == nil {
+		return nil
+	}
+
+	if desc != "" {
+		p.Summary, p.Description = newSummary("", desc)
+	}
+	p.Optional = !required
+	if deprecated {
+		p.Deprecated = i.deprecated()
+	}
+
+	return p
+}
+
+func (i *importer) securityRequirement(api *doc.API, req *SecurityRequirement, field string) {
+	if req == nil || len(*req) != 1 { // 空对象表示可以不验证，多个值表示需要同时满足，doc 均无法表示。
+		i.unsupported(field)
+		return
+	}
+
+	for name, scopes := range *req {
+		for _, s := range i.doc.Security {
+			if s.Name == name {
+				api.Security = append(api.Security, &doc.SecurityRequirement{Name: name, Scopes: scopes})
+				return
+			}
+		}
+		i.warn(field+"["+name+"]", locale.ErrNotFound)
+	}
+}
+
+func (i *importer) requestBody(body *RequestBody, field string) []*doc.Request {
+	if body == nil {
+		return nil
+	}
+
+	for depth := 0; body.Ref != ""; depth++ {
+		name, ok := i.componentName(body.Ref, "requestBodies", depth, field)
+		if !ok {
+			return nil
+		}
+		if body, ok = i.components.RequestBodies[name]; !ok {
+			i.warn(field+".$ref", locale.ErrNotFound)
+			return nil
+		}
+	}
+
+	reqs := make([]*doc.Request, 0, len(body.Content))
+	for _, mimetype := range sortedContentKeys(body.Content) {
+		req := i.request(mimetype, body.Content[mimetype], field+".content["+mimetype+"]")
+		if req == nil {
+			continue
+		}
+		if req.Summary == "" && req.Description.Text == "" {
+			req.Summary, req.Description = newSummary("", body.Description)
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs
+}
+
+func (i *importer) responses(responses map[string]*Response, field string) []*doc.Request {
+	reqs := make([]*doc.Request, 0, len(responses))
+
+	for _, status := range sortedResponseKeys(responses) {
+		f := field + "[" + status + "]"
+
+		code, err := strconv.Atoi(status)
+		if err != nil || code < http.StatusContinue || code > http.StatusNetworkAuthenticationRequired { // default 和 2XX 等无法表示
+			i.unsupported(f)
+			continue
+		}
+
+		resp := responses[status]
+		for depth := 0; resp.Ref != ""; depth++ {
+			name, ok := i.componentName(resp.Ref, "responses", depth, f)
+			if !ok {
+				resp = nil
+				break
+			}
+			if resp, ok = i.components.Responses[name]; !ok {
+				i.warn(f+".$ref", locale.ErrNotFound)
+				break
+			}
+		}
+		if resp == nil {
+			continue
+		}
+
+		if len(resp.Links) > 0 {
+			i.unsupported(f + ".links")
+		}
+
+		headers := i.headers(resp.Headers, f+".headers")
+		summary, desc := newSummary("", resp.Description)
+
+		if len(resp.Content) == 0 {
+			reqs = append(reqs, &doc.Request{
+				Status:      doc.Status(code),
+				Summary:     summary,
+				Description: desc,
+				Headers:     headers,
+			})
+			continue
+		}
+
+		for _, mimetype := range sortedContentKeys(resp.Content) {
+			req := i.request(mimetype, resp.Content[mimetype], f+".content["+mimetype+"]")
+			if req == nil {
+				continue
+			}
+			req.Status = doc.Status(code)
+			req.Headers = headers
+			if req.Summary == "" && req.Description.Text == "" {
+				req.Summary, req.Description = summary, desc
+			}
+			reqs = append(reqs, req)
+		}
+	}
+
+	return reqs
+}
+
+func (i *importer) headers(headers map[string]*Header, field string) []*doc.Param {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]*doc.Param, 0, len(names))
+	for _, name := range names {
+		f := field + "[" + name + "]"
+		h := (*Parameter)(headers[name])
+		for depth := 0; h.Ref != ""; depth++ {
+			n, ok := i.componentName(h.Ref, "headers", depth, f)
+			if !ok {
+				h = nil
+				break
+			}
+			hh, found := i.components.Headers[n]
+			if !found {
+				i.warn(f+".$ref", locale.ErrNotFound)
+				h = nil
+				break
+			}
+			h = (*Parameter)(hh)
+		}
+		if h == nil {
+			continue
+		}
+
+		if p := i.newParam(name, h.Description, h.Required, h.Deprecated, h.Schema, h.Content, f); p != nil {
+			params = append(params, p)
+		}
+	}
+
+	return params
+}
+
+// 解析 Parameter 的引用，返回 nil 表示无法解析。
+func (i *importer) parameter(p *Parameter, field string) *Parameter {
+	for depth := 0; p.Ref != ""; depth++ {
+		name, ok := i.componentName(p.Ref, "parameters", depth, field)
+		if !ok {
+			return nil
+		}
+		if p, ok = i.components.Parameters[name]; !ok {
+			i.warn(field+".$ref", locale.ErrNotFound)
+			return nil
+		}
+	}
+	return p
+}
+
+// 转换请求或是返回的报文，mimetype 无效时返回 nil。
+func (i *importer) request(mimetype string, mt *MediaType, field string) *doc.Request {
+	if mimetype == "" {
+		i.unsupported(field)
+		return nil
+	}
+	i.addMimetype(mimetype)
+
+	req := &doc.Request{Mimetype: mimetype}
+	if mt.Schema != nil {
+		if p := i.param("", mt.Schema, field+".schema"); p != nil {
+			req.XML = p.XML
+			req.Constraint = p.Constraint
+			req.Type = p.Type
+			req.Deprecated = p.Deprecated
+			req.Enums = p.Enums
+			req.Array = p.Array
+			req.Items = p.Items
+			req.Reference = p.Reference
+			req.OneOf = p.OneOf
+			req.AnyOf = p.AnyOf
+			req.Summary = p.Summary
+			req.Description = p.Description
+		}
+
+		if mt.Schema.XML != nil {
+			req.Name = mt.Schema.XML.Name
+		}
+	}
+
+	if mt.Example != "" {
+		req.Examples = append(req.Examples, &doc.Example{Mimetype: mimetype, Content: string(mt.Example)})
+	}
+
+	names := make([]string, 0, len(mt.Examples))
+	for name := range mt.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := field + ".examples[" + name + "]"
+		exp := mt.Examples[name]
+		for depth := 0; exp.Ref != ""; depth++ {
+			n, ok := i.componentName(exp.Ref, "examples", depth, f)
+			if !ok {
+				exp = nil
+				break
+			}
+			if exp, ok = i.components.Examples[n]; !ok {
+				i.warn(f+".$ref", locale.ErrNotFound)
+				break
+			}
+		}
+		if exp == nil {
+			continue
+		}
+
+		if exp.Value == "" { // 外部的示例代码无法表示
+			i.unsupported(f)
+			continue
+		}
+
+		e := &doc.Example{Mimetype: mimetype, Content: string(exp.Value)}
+		e.Summary, e.Description = newSummary(exp.Summary, exp.Description)
+		req.Examples = append(req.Examples, e)
+	}
+
+	if len(mt.Encoding) > 0 {
+		i.unsupported(field + ".encoding")
+	}
+
+	return req
+}
+
+// 获取 ref 引用的 Components 中 kind 类型的对象名称
+//
+// 仅支持引用当前文档的 #/components/{kind}/{name}，depth 为当前引用链的深度。
+func (i *importer) componentName(ref, kind string, depth int, field string) (string, bool) {
+	if depth >= maxRefDepth {
+		i.warn(field+".$ref", locale.ErrCircularReference)
+		return "", false
+	}
+
+	prefix := "#/components/" + kind + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		i.unsupported(field + ".$ref")
+		return "", false
+	}
+
+	// JSON Pointer 中的转义字符
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(strings.TrimPrefix(ref, prefix)), true
+}
+
+// 将 OpenAPI 的描述内容转换成 doc 中的 summary 和 description
+//
+// 未指定 title 时，单行的 desc 作为 summary，多行的作为 description；
+// OpenAPI 的 description 采用 CommonMark 语法，所以 description 的类型为 markdown。
+func newSummary(title, desc string) (string, doc.Richtext) {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return title, doc.Richtext{}
+	}
+
+	if title == "" && !strings.ContainsRune(desc, '\n') {
+		return desc, doc.Richtext{}
+	}
+
+	return title, doc.Richtext{Type: doc.RichtextTypeMarkdown, Text: desc}
+}
+
+func sortedContentKeys(content map[string]*MediaType) []string {
+	keys := make([]string, 0, len(content))
+	for key := range content {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,429 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/internal/locale"
+)
+
+// 转换 Components.Schemas 中名为 name 的对象，返回值表示是否转换成功。
+//
+// 转换后的内容保存在 doc.Types 中，同一对象只会被转换一次。
+func (i *importer) typ(name, field string) bool {
+	if p, found := i.types[name]; found {
+		return p != nil
+	}
+
+	if i.resolving[name] { // doc.Types 不支持循环引用
+		i.warn(field, locale.ErrCircularReference)
+		return false
+	}
+
+	s, found := i.components.Schemas[name]
+	if !found {
+		i.warn(field, locale.ErrNotFound)
+		return false
+	}
+
+	i.resolving[name] = true
+	p := i.param(name, s, "components.schemas["+name+"]")
+	delete(i.resolving, name)
+
+	i.types[name] = p
+	if p != nil {
+		i.doc.Types = append(i.doc.Types, p)
+	}
+	return p != nil
+}
+
+// 将 s 转换成名为 name 的 doc.Param，无法转换时返回 nil。
+//
+// 引用 Components.Schemas 的对象会被转换成对 doc.Types 的引用。
+func (i *importer) param(name string, s *Schema, field string) *doc.Param {
+	i.checkSchema(s, field)
+
+	var p *doc.Param
+	switch {
+	case s.Ref != "":
+		typ, ok := i.componentName(s.Ref, "schemas", 0, field)
+		if !ok || !i.typ(typ, field+".$ref") {
+			return nil
+		}
+		p = &doc.Param{Name: name, Reference: typ}
+	case len(s.AllOf) > 0:
+		p = i.allOf(name, s, field)
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0:
+		p = i.union(name, s, field)
+	case s.Type == TypeArray || s.Items != nil:
+		p = i.array(name, s, field)
+	case s.Type == TypeObject || len(s.Properties) > 0 || s.AdditionalProperties != nil:
+		p = i.object(name, s, field)
+	default:
+		p = i.primitive(name, s, field)
+	}
+	if p == nil {
+		return nil
+	}
+
+	if s.Title != "" || s.Description != "" {
+		p.Summary, p.Description = newSummary(s.Title, s.Description)
+	}
+	if p.Summary == "" && p.Description.Text == "" && p.Reference == "" {
+		p.Summary = name
+	}
+
+	if s.Deprecated {
+		p.Deprecated = i.deprecated()
+	}
+	if s.Default != nil {
+		p.Default = valueString(s.Default)
+	}
+	p.Nullable = p.Nullable || s.Nullable
+	if s.ReadOnly || s.WriteOnly {
+		p.ReadOnly = s.ReadOnly
+		p.WriteOnly = s.WriteOnly && !s.ReadOnly
+	}
+
+	return p
+}
+
+// 检测 s 中无法转换的字段
+func (i *importer) checkSchema(s *Schema, field string) {
+	unsupported := []struct {
+		name  string
+		found bool
+	}{
+		{"not", s.Not != nil},
+		{"multipleOf", s.MultipleOf != 0},
+		{"exclusiveMaximum", s.ExclusiveMaximum},
+		{"exclusiveMinimum", s.ExclusiveMinimum},
+		{"additionalItems", s.AdditionalItems != nil},
+		{"uniqueItems", s.UniqueItems},
+		{"contains", s.Contains != nil},
+		{"maxProperties", s.MaxProperties != 0},
+		{"minProperties", s.MinProperties != 0},
+		{"patternProperties", len(s.PatternProperties) > 0},
+		{"dependencies", len(s.Dependencies) > 0},
+		{"propertyNames", s.PropertyNames != nil},
+		{"definitions", len(s.Definitions) > 0},
+		{"externalDocs", s.ExternalDocs != nil},
+		{"example", s.Example != ""},
+	}
+
+	for _, item := range unsupported {
+		if item.found {
+			i.unsupported(field + "." + item.name)
+		}
+	}
+}
+
+// 合并 allOf 中的所有对象
+//
+// 仅包含一个元素的 allOf 一般用于给引用的对象添加 nullable 等属性，直接转换该元素即可。
+func (i *importer) allOf(name string, s *Schema, field string) *doc.Param {
+	if len(s.AllOf) == 1 && len(s.Properties) == 0 && s.Type == "" {
+		return i.param(name, s.AllOf[0], field+".allOf[0]")
+	}
+
+	merged := &Schema{
+		Type:                 TypeObject,
+		Properties:           make(map[string]*Schema, len(s.Properties)),
+		AdditionalProperties: s.AdditionalProperties,
+	}
+	if !i.merge(merged, s, 0, field) {
+		return nil
+	}
+	return i.object(name, merged, field)
+}
+
+// 将 s 及其 allOf 中的属性合并到 dest
+func (i *importer) merge(dest, s *Schema, depth int, field string) bool {
+	for index, item := range s.AllOf {
+		f := field + ".allOf[" + strconv.Itoa(index) + "]"
+
+		for d := depth; item.Ref != ""; d++ {
+			name, ok := i.componentName(item.Ref, "schemas", d, f)
+			if !ok {
+				return false
+			}
+			if item, ok = i.components.Schemas[name]; !ok {
+				i.warn(f+".$ref", locale.ErrNotFound)
+				return false
+			}
+			depth = d + 1
+		}
+
+		if !i.merge(dest, item, depth+1, f) {
+			return false
+		}
+	}
+
+	if s.Type != "" && s.Type != TypeObject {
+		i.unsupported(field + ".allOf")
+		return false
+	}
+
+	for name, prop := range s.Properties {
+		dest.Properties[name] = prop
+	}
+	dest.Required = append(dest.Required, s.Required...)
+	return true
+}
+
+// 将 oneOf 或 anyOf 转换成 doc.Union
+//
+// 子类型的名称优先采用 discriminator 中对应的值，其次是引用的类型名称。
+func (i *importer) union(name string, s *Schema, field string) *doc.Param {
+	items, key := s.OneOf, "oneOf"
+	if len(items) == 0 {
+		items, key = s.AnyOf, "anyOf"
+	} else if len(s.AnyOf) > 0 {
+		i.unsupported(field + ".anyOf")
+	}
+
+	u := &doc.Union{Items: make([]*doc.Param, 0, len(items))}
+
+	for index, item := range items {
+		itemName := "item" + strconv.Itoa(index+1)
+		if item.Ref != "" {
+			if n, ok := i.componentName(item.Ref, "schemas", 0, field); ok {
+				itemName = n
+			}
+
+			if s.Discriminator != nil {
+				for value, ref := range s.Discriminator.Mapping {
+					if ref == item.Ref {
+						itemName = value
+						break
+					}
+				}
+			}
+		}
+
+		if p := i.param(itemName, item, field+"."+key+"["+strconv.Itoa(index)+"]"); p != nil {
+			u.Items = append(u.Items, p)
+		}
+	}
+
+	if len(u.Items) == 0 {
+		return nil
+	}
+
+	if s.Discriminator != nil {
+		if i.checkDiscriminator(u.Items, s.Discriminator.PropertyName) {
+			u.Discriminator = s.Discriminator.PropertyName
+		} else {
+			i.unsupported(field + ".discriminator")
+		}
+	}
+
+	p := &doc.Param{Name: name}
+	if key == "oneOf" {
+		p.OneOf = u
+	} else {
+		p.AnyOf = u
+	}
+	return p
+}
+
+// doc.Union 要求所有的子类型都是包含该字符串属性的对象
+func (i *importer) checkDiscriminator(items []*doc.Param, prop string) bool {
+	for _, item := range items {
+		if item.Array {
+			return false
+		}
+		if item.Reference != "" {
+			item = i.types[item.Reference]
+		}
+		if item == nil || item.Type != doc.Object || item.Array {
+			return false
+		}
+
+		found := false
+		for _, p := range item.Items {
+			if p.Name == prop && p.Type == doc.String && !p.Array {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// doc.Param 只能表示一维数组
+func (i *importer) array(name string, s *Schema, field string) *doc.Param {
+	if s.Items == nil {
+		i.unsupported(field + ".items")
+		return &doc.Param{Name: name, Type: doc.String, Array: true}
+	}
+
+	elem := i.param(name, s.Items, field+".items")
+	if elem == nil {
+		return nil
+	}
+	if elem.Array {
+		i.unsupported(field + ".items")
+	}
+
+	p := *elem
+	p.Array = true
+	p.MinItems = s.MinItems
+	p.MaxItems = s.MaxItems
+	p.XMLAttr = false
+	if s.XML != nil && s.XML.Wrapped && name != "" {
+		p.XMLWrapped = name
+	}
+	return &p
+}
+
+// 对象的属性按名称排序；没有属性的对象会被转换成字典类型。
+//
+// 属性无法转换时（比如循环引用）仅忽略该属性，所有属性都无法转换时返回 nil。
+func (i *importer) object(name string, s *Schema, field string) *doc.Param {
+	p := &doc.Param{Name: name, Type: doc.Object}
+
+	required := make(map[string]bool, len(s.Required))
+	for _, item := range s.Required {
+		required[item] = true
+	}
+
+	for _, key := range sortedKeys(s.Properties) {
+		if item := i.param(key, s.Properties[key], field+".properties["+key+"]"); item != nil {
+			item.Optional = !required[key]
+			p.Items = append(p.Items, item)
+		}
+	}
+
+	additional := s.AdditionalProperties
+	if additional != nil && additional.Not != nil && isEmptySchema(additional.Not) { // additionalProperties: false
+		additional = nil
+	}
+
+	if len(s.Properties) > 0 {
+		if len(p.Items) == 0 {
+			return nil
+		}
+		if additional != nil {
+			i.unsupported(field + ".additionalProperties")
+		}
+		return p
+	}
+
+	// 字典的值可以是任意类型，doc 无法表示，以字符串代替。
+	p.Type = doc.Map
+	var value *doc.Param
+	if additional != nil && !isEmptySchema(additional) {
+		value = i.param("key", additional, field+".additionalProperties")
+	}
+	if value == nil {
+		i.unsupported(field + ".additionalProperties")
+		value = &doc.Param{Name: "key", Type: doc.String, Summary: "key"}
+	}
+	value.Optional = false
+	p.Items = []*doc.Param{value}
+
+	return p
+}
+
+func (i *importer) primitive(name string, s *Schema, field string) *doc.Param {
+	p := &doc.Param{Name: name}
+
+	typ := s.Type
+	if typ == "" && len(s.Enum) > 0 { // 未指定类型的枚举，根据枚举值判断类型。
+		switch s.Enum[0].(type) {
+		case bool:
+			typ = "boolean"
+		case int, int64, uint64, float64:
+			typ = TypeNumber
+		default:
+			typ = TypeString
+		}
+	}
+
+	switch typ {
+	case TypeString, TypePassword:
+		p.Type = doc.String
+		p.MinLength = s.MinLength
+		p.MaxLength = s.MaxLength
+		p.Pattern = s.Pattern
+
+		switch s.Format {
+		case "":
+		case doc.FormatDate, doc.FormatDateTime, doc.FormatEmail, doc.FormatUUID, doc.FormatURI, doc.FormatBinary:
+			p.Format = s.Format
+		default:
+			i.unsupported(field + ".format")
+		}
+	case TypeInt, TypeLong:
+		p.Type = doc.Integer
+	case TypeNumber, TypeFloat, TypeDouble:
+		p.Type = doc.Number
+		if s.Format == TypeFloat || s.Format == TypeDouble {
+			p.Type = doc.Float
+		}
+	case "boolean", TypeBool:
+		p.Type = doc.Bool
+	default: // 未指定类型的表示可以是任意值，doc 无法表示，以字符串代替。
+		i.unsupported(field + ".type")
+		p.Type = doc.String
+	}
+
+	if p.Type.IsNumber() {
+		p.Min = s.Minimum
+		p.Max = s.Maximum
+	}
+
+	for _, v := range s.Enum {
+		if v == nil { // nullable 的枚举值中可以包含 null
+			continue
+		}
+		value := valueString(v)
+		if value == "" {
+			i.unsupported(field + ".enum")
+			continue
+		}
+		p.Enums = append(p.Enums, &doc.Enum{Value: value, Summary: value})
+	}
+
+	if s.XML != nil {
+		p.XMLAttr = s.XML.Attribute
+		if s.XML.Prefix != "" && !s.XML.Attribute {
+			p.XMLNS = s.XML.Namespace
+			p.XMLNSPrefix = s.XML.Prefix
+		}
+	}
+
+	return p
+}
+
+func isEmptySchema(s *Schema) bool {
+	return reflect.DeepEqual(s, &Schema{})
+}
+
+// 将 Schema 中的 default 和 enum 等值转换成字符串，非基本类型的值以 JSON 格式表示。
+func valueString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case map[interface{}]interface{}, []interface{}:
+		data, err := json.Marshal(jsonValue(val))
+		if err != nil { // 由 yaml 解析而来的值，不会出错。
+			panic(err)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(val)
+	}
+}
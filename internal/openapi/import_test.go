@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/doc/doctest"
+	"github.com/caixw/apidoc/v6/internal/locale"
+)
+
+const petstore = `openapi: "3.0.0"
+info:
+  version: v1.2
+  title: petstore
+servers:
+  - url: https://{env}.example.com
+    variables:
+      env:
+        default: api
+paths:
+  /pets/{id}:
+    parameters:
+      - $ref: "#/components/parameters/ID"
+    get:
+      tags: [pets]
+      parameters:
+        - name: size
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Pet"
+        default:
+          description: error
+    trace:
+      responses:
+        "200":
+          description: ok
+components:
+  parameters:
+    ID:
+      name: id
+      in: path
+      required: true
+      schema:
+        type: string
+  schemas:
+    Pet:
+      type: object
+      additionalProperties: false
+      required: [name]
+      properties:
+        name:
+          type: string
+        status:
+          enum: [sold, available]
+          default: sold
+        parent:
+          $ref: "#/components/schemas/Pet"
+        attrs:
+          type: object
+          additionalProperties:
+            type: integer
+    Pets:
+      oneOf:
+        - $ref: "#/components/schemas/Pet"
+        - type: object
+          properties:
+            name:
+              type: string
+            size:
+              type: integer
+      discriminator:
+        propertyName: name
+`
+
+func TestUnmarshal(t *testing.T) {
+	a := assert.New(t)
+
+	oa, err := Unmarshal([]byte(petstore))
+	a.NotError(err).NotNil(oa)
+	a.Equal(oa.Info.Title, "petstore").
+		Equal(len(oa.Paths), 1).
+		Equal(len(oa.Components.Schemas), 2)
+	a.NotNil(oa.Components.Schemas["Pet"].AdditionalProperties.Not)
+
+	oa, err = Unmarshal([]byte(`{"openapi":"2.0","info":{"title":"t"}}`))
+	a.Error(err).Nil(oa)
+
+	oa, err = Unmarshal([]byte(`{"openapi":"3.0.0"}`))
+	a.Error(err).Nil(oa)
+
+	oa, err = Unmarshal([]byte(`{"openapi":"3.0.0","info":{}}`))
+	a.Error(err).Nil(oa)
+}
+
+func TestImport(t *testing.T) {
+	a := assert.New(t)
+
+	oa, err := Unmarshal([]byte(petstore))
+	a.NotError(err).NotNil(oa)
+
+	d, warns := Import(oa)
+	a.NotNil(d)
+	a.Equal(d.Version, "1.2.0").
+		Equal(d.Mimetypes, []string{"application/json"}).
+		Equal(len(d.Tags), 1)
+	a.Equal(len(d.Servers), 1).
+		Equal(d.Servers[0].URL, "https://api.example.com")
+
+	a.Equal(len(d.Types), 2)
+	pet := d.Types[0]
+	a.Equal(pet.Name, "Pet").
+		Equal(pet.Type, doc.Object).
+		Equal(len(pet.Items), 3) // parent 为循环引用
+	a.Equal(pet.Items[0].Name, "attrs").
+		Equal(pet.Items[0].Type, doc.Map).
+		Equal(pet.Items[0].Items[0].Type, doc.Integer)
+	a.Equal(pet.Items[1].Name, "name").False(pet.Items[1].Optional)
+	a.Equal(pet.Items[2].Name, "status").
+		True(pet.Items[2].Optional).
+		Equal(pet.Items[2].Type, doc.String).
+		Equal(pet.Items[2].Default, "sold").
+		Equal(len(pet.Items[2].Enums), 2)
+
+	pets := d.Types[1]
+	a.NotNil(pets.OneOf).
+		Equal(pets.OneOf.Discriminator, "name").
+		Equal(len(pets.OneOf.Items), 2).
+		Equal(pets.OneOf.Items[0].Reference, "Pet")
+
+	a.Equal(len(d.Apis), 1)
+	api := d.Apis[0]
+	a.Equal(api.Method, "GET").
+		Equal(api.Servers, []string{"server1"}).
+		Equal(api.Tags, []string{"pets"})
+	a.Equal(len(api.Path.Params), 1).
+		Equal(api.Path.Params[0].Name, "id").
+		False(api.Path.Params[0].Optional)
+	a.Equal(len(api.Path.Queries), 1).
+		True(api.Path.Queries[0].Optional).
+		Equal(*api.Path.Queries[0].Max, 100.0)
+	a.Equal(len(api.Responses), 1).
+		Equal(api.Responses[0].Reference, "Pet").
+		True(api.Responses[0].Array)
+
+	fields := make([]string, 0, len(warns))
+	for _, w := range warns {
+		fields = append(fields, w.Field)
+	}
+	a.Equal(fields, []string{
+		"servers[0].variables",
+		"components.schemas[Pet].properties[parent].$ref",
+		"paths[/pets/{id}].get.responses[default]",
+		"paths[/pets/{id}].trace",
+	})
+	a.Equal(warns[1].Message, locale.Sprintf(locale.ErrCircularReference))
+
+	// 转换后的内容应该是合法的文档
+	data, err := xml.Marshal(d)
+	a.NotError(err)
+	d2 := doc.New()
+	a.NotError(d2.FromXML("import.xml", 0, data))
+	a.NotError(d2.Sanitize())
+}
+
+func TestImport_recursive(t *testing.T) {
+	a := assert.New(t)
+
+	oa, err := Unmarshal([]byte(`openapi: "3.0.0"
+info:
+  title: tree
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        name:
+          type: string
+        children:
+          type: array
+          items:
+            $ref: "#/components/schemas/Node"
+    Leaf:
+      type: object
+      properties:
+        next:
+          $ref: "#/components/schemas/Leaf"
+`))
+	a.NotError(err).NotNil(oa)
+
+	d, warns := Import(oa)
+	a.NotNil(d)
+
+	// Leaf 的所有属性都是循环引用，无法转换。
+	a.Equal(len(d.Types), 1)
+	node := d.Types[0]
+	a.Equal(node.Name, "Node").
+		Equal(node.Type, doc.Object).
+		Equal(len(node.Items), 1).
+		Equal(node.Items[0].Name, "name")
+
+	fields := make([]string, 0, len(warns))
+	for _, w := range warns {
+		a.Equal(w.Message, locale.Sprintf(locale.ErrCircularReference))
+		fields = append(fields, w.Field)
+	}
+	a.Equal(fields, []string{
+		"components.schemas[Leaf].properties[next].$ref",
+		"components.schemas[Node].properties[children].items.$ref",
+	})
+
+	data, err := xml.Marshal(d)
+	a.NotError(err)
+	d2 := doc.New()
+	a.NotError(d2.FromXML("import.xml", 0, data))
+	a.NotError(d2.Sanitize())
+}
+
+func TestImport_JSON(t *testing.T) {
+	a := assert.New(t)
+
+	data, err := JSON(doctest.Get())
+	a.NotError(err).NotNil(data)
+	oa, err := Unmarshal(data)
+	a.NotError(err).NotNil(oa)
+
+	d, _ := Import(oa)
+	a.NotNil(d)
+	a.Equal(d.Title, "test").
+		Equal(len(d.Apis), 2)
+
+	data, err = xml.Marshal(d)
+	a.NotError(err)
+	d2 := doc.New()
+	a.NotError(d2.FromXML("import.xml", 0, data))
+	a.NotError(d2.Sanitize())
+}
+
+func TestValueString(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(valueString(nil), "").
+		Equal(valueString("str"), "str").
+		Equal(valueString(5), "5").
+		Equal(valueString(true), "true").
+		Equal(valueString([]interface{}{1, "2"}), `[1,"2"]`).
+		Equal(valueString(map[interface{}]interface{}{"k": 1}), `{"k":1}`)
+}
@@ -6,6 +6,8 @@
 package openapi
 
 import (
+	"encoding/json"
+	"fmt"
 	"strconv"
 
 	"github.com/issue9/is"
@@ -39,7 +41,7 @@ type Components struct {
 	Examples        map[string]*Example        `json:"examples,omitempty" yaml:"examples,omitempty"`
 	RequestBodies   map[string]*RequestBody    `json:"requestBodies,omitempty" yaml:"requestBodies,omitempty"`
 	Headers         map[string]*Header         `json:"headers,omitempty" yaml:"headers,omitempty"`
-	SecuritySchemes map[string]*SecurityScheme `json:"securitySechemes,omitempty" yaml:"securitySechemes,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
 	Links           map[string]*Link           `json:"links,omitempty" yaml:"links,omitempty"`
 	Callbacks       map[string]*Callback       `json:"callbacks,omitempty" yaml:"callbacks,omitempty"`
 }
@@ -82,6 +84,47 @@ type Example struct {
 // ExampleValue 表示示例的内容类型。
 type ExampleValue string
 
+// UnmarshalYAML yaml.Unmarshaler
+//
+// 示例可以是任意类型的值，非字符串的值会被转换成 JSON 格式的字符串。
+func (v *ExampleValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var val interface{}
+	if err := unmarshal(&val); err != nil {
+		return err
+	}
+
+	if str, ok := val.(string); ok {
+		*v = ExampleValue(str)
+		return nil
+	}
+
+	data, err := json.MarshalIndent(jsonValue(val), "", "    ")
+	if err != nil {
+		return err
+	}
+	*v = ExampleValue(data)
+	return nil
+}
+
+// 将 yaml 解析出来的 map[interface{}]interface{} 转换成 json 可以处理的 map[string]interface{}
+func jsonValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = jsonValue(item)
+		}
+		return m
+	case []interface{}:
+		for index, item := range v {
+			v[index] = jsonValue(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
 func newTag(tag *doc.Tag) *Tag {
 	return &Tag{
 		Name:        tag.Name,
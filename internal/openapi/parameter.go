@@ -21,7 +21,7 @@ type Header Parameter
 // Parameter 参数信息
 // 可同时作用于路径参数、请求参数、报头内容和 Cookie 值。
 type Parameter struct {
-	Style
+	Style           `yaml:",inline"`
 	Name            string                `json:"name,omitempty" yaml:"name,omitempty"`
 	IN              string                `json:"in,omitempty" yaml:"in,omitempty"`
 	Description     string                `json:"description,omitempty" yaml:"description,omitempty"`
@@ -10,16 +10,14 @@ import (
 
 	"github.com/caixw/apidoc/v6/doc"
 	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/internal/render"
 	"github.com/caixw/apidoc/v6/internal/vars"
 	"github.com/caixw/apidoc/v6/message"
 )
 
 // 将 doc.Doc 转换成 openapi
 func convert(doc *doc.Doc) (*OpenAPI, error) {
-	langID := doc.Lang
-	if langID == "" {
-		langID = "und"
-	}
+	langID := render.LangID(doc)
 
 	openapi := &OpenAPI{
 		OpenAPI: LatestVersion,
@@ -51,6 +49,8 @@ func convert(doc *doc.Doc) (*OpenAPI, error) {
 		return nil, err
 	}
 
+	parseComponents(openapi, doc)
+
 	if err := openapi.sanitize(); err != nil {
 		return nil, err
 	}
@@ -76,6 +76,7 @@ func parsePaths(openapi *OpenAPI, d *doc.Doc) *message.SyntaxError {
 		operation.OperationID = api.ID
 		operation.Summary = api.Summary
 		operation.Description = api.Description.Text
+		operation.Security = newSecurityRequirements(api.Security)
 		setOperationParams(operation, api)
 
 		// servers
@@ -126,40 +127,57 @@ func parsePaths(openapi *OpenAPI, d *doc.Doc) *message.SyntaxError {
 
 		// responses
 		operation.Responses = make(map[string]*Response, len(api.Responses))
-		for _, resp := range api.Responses {
-			status := resp.Status.String()
-			r, found := operation.Responses[status]
-			if !found {
-				r = &Response{
-					Description: getDescription(resp.Description.Text, resp.Summary),
-					Headers:     make(map[string]*Header, 10),
-					Content:     make(map[string]*MediaType, 10),
-				}
-				operation.Responses[status] = r
+		setResponses(operation.Responses, api.Responses)
+	} // end for doc.Apis
+
+	return nil
+}
+
+// 将 resps 转换成 Response 对象写入 responses，相同状态码的内容会被合并。
+func setResponses(responses map[string]*Response, resps []*doc.Request) {
+	for _, resp := range resps {
+		status := resp.Status.String()
+		r, found := responses[status]
+		if !found {
+			r = &Response{
+				Description: getDescription(resp.Description.Text, resp.Summary),
+				Headers:     make(map[string]*Header, 10),
+				Content:     make(map[string]*MediaType, 10),
 			}
+			responses[status] = r
+		}
 
-			for _, h := range resp.Headers {
-				r.Headers[h.Name] = &Header{
-					Style:       Style{Style: StyleSimple},
-					Description: getDescription(h.Description.Text, h.Summary),
-				}
+		for _, h := range resp.Headers {
+			r.Headers[h.Name] = &Header{
+				Style:       Style{Style: StyleSimple},
+				Description: getDescription(h.Description.Text, h.Summary),
 			}
+		}
 
-			examples := make(map[string]*Example, len(resp.Examples))
-			for _, exp := range resp.Examples {
-				examples[exp.Mimetype] = &Example{
-					Summary: getDescription(exp.Description.Text, exp.Summary),
-					Value:   ExampleValue(exp.Content),
-				}
+		// openapi 无法单独描述每一个 cookie，统一以 Set-Cookie 报头的形式输出。
+		if len(resp.Cookies) > 0 {
+			names := make([]string, 0, len(resp.Cookies))
+			for _, c := range resp.Cookies {
+				names = append(names, c.Name)
 			}
-			r.Content[resp.Mimetype] = &MediaType{
-				Schema:   newSchemaFromRequest(resp, true),
-				Examples: examples,
+			r.Headers["Set-Cookie"] = &Header{
+				Style:       Style{Style: StyleSimple},
+				Description: strings.Join(names, ", "),
 			}
 		}
-	} // end for doc.Apis
 
-	return nil
+		examples := make(map[string]*Example, len(resp.Examples))
+		for _, exp := range resp.Examples {
+			examples[exp.Mimetype] = &Example{
+				Summary: getDescription(exp.Description.Text, exp.Summary),
+				Value:   ExampleValue(exp.Content),
+			}
+		}
+		r.Content[resp.Mimetype] = &MediaType{
+			Schema:   newSchemaFromRequest(resp, true),
+			Examples: examples,
+		}
+	}
 }
 
 func setOperationParams(operation *Operation, api *doc.API) {
@@ -197,6 +215,30 @@ func setOperationParams(operation *Operation, api *doc.API) {
 			})
 		}
 	}
+
+	// API 和各个 Request 中的 cookie，同名的只保留第一个。
+	cookies := make(map[string]bool, len(api.Cookies))
+	addCookies := func(params []*doc.Param) {
+		for _, param := range params {
+			if cookies[param.Name] {
+				continue
+			}
+			cookies[param.Name] = true
+
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Style:       Style{Style: StyleForm},
+				Name:        param.Name,
+				IN:          ParameterINCookie,
+				Description: getDescription(param.Description.Text, param.Summary),
+				Required:    !param.Optional,
+				Schema:      newSchema(param, true),
+			})
+		}
+	}
+	addCookies(api.Cookies)
+	for _, r := range api.Requests {
+		addCookies(r.Cookies)
+	}
 }
 
 func getDescription(desc, summary string) string {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/issue9/assert"
 
+	"github.com/caixw/apidoc/v6/doc"
 	"github.com/caixw/apidoc/v6/doc/doctest"
 	"github.com/caixw/apidoc/v6/internal/vars"
 )
@@ -43,3 +44,50 @@ func TestYAML(t *testing.T) {
 	data, err := YAML(doctest.Get())
 	a.NotError(err).NotNil(data)
 }
+
+func TestSetOperationParams(t *testing.T) {
+	a := assert.New(t)
+
+	api := &doc.API{
+		Path: &doc.Path{
+			Path:    "/users/{id}",
+			Params:  []*doc.Param{{Name: "id", Type: doc.Number}},
+			Queries: []*doc.Param{{Name: "page", Type: doc.Number, Optional: true}},
+		},
+		Cookies: []*doc.Param{{Name: "session", Type: doc.String}},
+		Requests: []*doc.Request{
+			{
+				Headers: []*doc.Param{{Name: "encoding", Type: doc.String}},
+				Cookies: []*doc.Param{
+					{Name: "session", Type: doc.String},
+					{Name: "lang", Type: doc.String, Optional: true},
+				},
+			},
+		},
+	}
+
+	o := &Operation{}
+	setOperationParams(o, api)
+	a.Equal(5, len(o.Parameters))
+
+	session := o.Parameters[3]
+	a.Equal(session.Name, "session").
+		Equal(session.IN, ParameterINCookie).
+		True(session.Required).
+		Equal(session.Schema.Type, TypeString)
+
+	lang := o.Parameters[4]
+	a.Equal(lang.Name, "lang").
+		Equal(lang.IN, ParameterINCookie).
+		False(lang.Required)
+
+	// 返回的 cookie 以 Set-Cookie 报头的形式输出
+	responses := map[string]*Response{}
+	setResponses(responses, []*doc.Request{
+		{
+			Status:  http.StatusOK,
+			Cookies: []*doc.Param{{Name: "session", Type: doc.String}, {Name: "lang", Type: doc.String}},
+		},
+	})
+	a.Equal(responses["200"].Headers["Set-Cookie"].Description, "session, lang")
+}
@@ -11,7 +11,7 @@ import (
 
 // PathItem 每一条路径的详细描述信息
 type PathItem struct {
-	Ref         string       `json:"ref,omitempty" yaml:"ref,omitempty"`
+	Ref         string       `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	Summary     string       `json:"summary,omitempty" yaml:"summary,omitempty"`
 	Description string       `json:"description,omitempty" yaml:"description,omitempty"`
 	Get         *Operation   `json:"get,omitempty" yaml:"get,omitempty"`
@@ -63,7 +63,7 @@ type MediaType struct {
 //
 // 对父对象中的 Schema 中的一些字段的特殊定义
 type Encoding struct {
-	Style
+	Style       `yaml:",inline"`
 	ContentType string             `json:"contentType,omitempty" yaml:"contentType,omitempty"`
 	Headers     map[string]*Header `json:"headers,omitempty" yaml:"headers,omitempty"`
 }
@@ -168,6 +168,10 @@ func (req *RequestBody) sanitize() *message.SyntaxError {
 }
 
 func (resp *Response) sanitize() *message.SyntaxError {
+	if resp.Ref != "" {
+		return nil
+	}
+
 	if resp.Description == "" {
 		return message.NewLocaleError("", "description", 0, locale.ErrRequired)
 	}
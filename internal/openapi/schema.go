@@ -3,6 +3,9 @@
 package openapi
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/caixw/apidoc/v6/doc"
 	"github.com/caixw/apidoc/v6/message"
 )
@@ -10,6 +13,7 @@ import (
 // Schema.Type 需要的一些预定义数据类型
 const (
 	TypeInt      = "integer"
+	TypeNumber   = "number"
 	TypeLong     = "long"
 	TypeFloat    = "float"
 	TypeDouble   = "double"
@@ -17,11 +21,17 @@ const (
 	TypeBool     = "bool"
 	TypePassword = "password"
 	TypeArray    = "array"
+	TypeObject   = "object"
 )
 
+// 引用 Components.Schemas 中对象的前缀
+const schemaRefPrefix = "#/components/schemas/"
+
 func fromDocType(t doc.Type) string {
 	switch string(t) {
-	case doc.Number:
+	case doc.Number, doc.Float:
+		return TypeNumber
+	case doc.Integer:
 		return TypeInt
 	case doc.String:
 		return TypeString
@@ -34,15 +44,16 @@ func fromDocType(t doc.Type) string {
 
 // Schema 定义了输出和输出的数据类型
 type Schema struct {
-	Type string        `json:"type,omitempty" yaml:"type,omitempty"`
-	Enum []interface{} `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Type   string        `json:"type,omitempty" yaml:"type,omitempty"`
+	Format string        `json:"format,omitempty" yaml:"format,omitempty"`
+	Enum   []interface{} `json:"enum,omitempty" yaml:"enum,omitempty"`
 
 	// 数值验证
-	MultipleOf       int  `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
-	Maximum          int  `json:"maximum,omitempty" yaml:"maximum,omitempty"`
-	ExclusiveMaximum bool `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
-	Minimum          int  `json:"minimum,omitempty" yaml:"minimum,omitempty"`
-	ExclusiveMinimum bool `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+	MultipleOf       float64  `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	ExclusiveMaximum bool     `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
 
 	// 字符串验证
 	MaxLength int    `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
@@ -63,7 +74,7 @@ type Schema struct {
 	Required             []string           `json:"required,omitempty" yaml:"required,omitempty"`
 	Properties           map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
 	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty" yaml:"patternProperties,omitempty"`
-	AdditionalProperties map[string]*Schema `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
 	Dependencies         map[string]*Schema `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	PropertyNames        *Schema            `json:"propertyNames,omitempty" yaml:"propertyNames,omitempty"`
 
@@ -79,6 +90,7 @@ type Schema struct {
 	Title         string                 `json:"title,omitempty" yaml:"title,omitempty"`
 	Description   string                 `json:"description,omitempty" yaml:"description,omitempty"`
 	Default       interface{}            `json:"default,omitempty" yaml:"default,omitempty"`
+	Nullable      bool                   `json:"nullable,omitempty" yaml:"nullable,omitempty"`
 	ReadOnly      bool                   `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
 	WriteOnly     bool                   `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"`
 	Discriminator *Discriminator         `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
@@ -88,6 +100,24 @@ type Schema struct {
 	Deprecated    bool                   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 }
 
+type shadowSchema Schema
+
+// UnmarshalYAML yaml.Unmarshaler
+//
+// JSON Schema 中 true 和 false 也是合法的 Schema，
+// 分别表示匹配任意值和不匹配任何值，比如 additionalProperties: false。
+func (s *Schema) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var b bool
+	if err := unmarshal(&b); err == nil {
+		if !b {
+			s.Not = &Schema{}
+		}
+		return nil
+	}
+
+	return unmarshal((*shadowSchema)(s))
+}
+
 // XML 将 Schema 转换为 XML 的相关声明
 type XML struct {
 	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
@@ -98,8 +128,6 @@ type XML struct {
 }
 
 // Discriminator Object
-//
-// NOTE: 暂时未用到。
 type Discriminator struct {
 	PropertyName string            `json:"propertyName" yaml:"propertyName"`
 	Mapping      map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
@@ -127,25 +155,80 @@ func (s *Schema) sanitize() *message.SyntaxError {
 		}
 	}
 
+	if s.AdditionalProperties != nil {
+		if err := s.AdditionalProperties.sanitize(); err != nil {
+			err.Field = "additionalProperties." + err.Field
+			return err
+		}
+	}
+
+	for index, obj := range s.OneOf {
+		if err := obj.sanitize(); err != nil {
+			err.Field = "oneOf[" + strconv.Itoa(index) + "]." + err.Field
+			return err
+		}
+	}
+
+	for index, obj := range s.AnyOf {
+		if err := obj.sanitize(); err != nil {
+			err.Field = "anyOf[" + strconv.Itoa(index) + "]." + err.Field
+			return err
+		}
+	}
+
 	return nil
 }
 
 // chkArray 是否需要检测当前类型是否为数组
+//
+// 如果 p 引用了 doc.Types 中的类型，则直接返回指向 Components.Schemas 的引用。
 func newSchema(p *doc.Param, chkArray bool) *Schema {
-	xml := &XML{
+	var s *Schema
+	if chkArray && p.Array {
+		s = &Schema{
+			Type:     TypeArray,
+			Items:    newElementSchema(p),
+			XML:      newXML(p),
+			MinItems: p.MinItems,
+			MaxItems: p.MaxItems,
+		}
+	} else {
+		s = newElementSchema(p)
+	}
+
+	if !p.Nullable && !p.ReadOnly && !p.WriteOnly {
+		return s
+	}
+
+	// $ref 的同级属性会被忽略，需要通过 allOf 包装一层。
+	if s.Ref != "" {
+		s = &Schema{AllOf: []*Schema{s}}
+	}
+	s.Nullable = p.Nullable
+	s.ReadOnly = p.ReadOnly
+	s.WriteOnly = p.WriteOnly
+
+	return s
+}
+
+func newXML(p *doc.Param) *XML {
+	return &XML{
 		Name:      p.Name,
 		Namespace: p.XMLNS,
 		Prefix:    p.XMLNSPrefix,
 		Attribute: p.XMLAttr,
 		Wrapped:   p.XMLWrapped != "",
 	}
+}
+
+// 生成 p 的元素类型，不考虑 p.Array 的值。
+func newElementSchema(p *doc.Param) *Schema {
+	if p.Reference != "" {
+		return &Schema{Ref: schemaRefPrefix + strings.TrimPrefix(p.Reference, "#")}
+	}
 
-	if chkArray && p.Array {
-		return &Schema{
-			Type:  TypeArray,
-			Items: newSchema(p, false),
-			XML:   xml,
-		}
+	if p.IsUnion() {
+		return newUnionSchema(p)
 	}
 
 	s := &Schema{
@@ -155,7 +238,13 @@ func newSchema(p *doc.Param, chkArray bool) *Schema {
 		Default:     p.Default,
 		Deprecated:  p.Deprecated != "",
 		Required:    make([]string, 0, len(p.Items)),
-		XML:         xml,
+		XML:         newXML(p),
+		Format:      p.Format,
+		Minimum:     p.Min,
+		Maximum:     p.Max,
+		MinLength:   p.MinLength,
+		MaxLength:   p.MaxLength,
+		Pattern:     p.Pattern,
 	}
 
 	// enum
@@ -166,6 +255,13 @@ func newSchema(p *doc.Param, chkArray bool) *Schema {
 		}
 	}
 
+	// 字典，子元素表示值的类型
+	if v := p.MapValue(); v != nil {
+		s.Type = TypeObject
+		s.AdditionalProperties = newSchema(v, true)
+		return s
+	}
+
 	// Properties / Required
 	if len(p.Items) > 0 { // 如果是对象，类型改为空
 		s.Type = ""
@@ -187,6 +283,50 @@ func newSchema(p *doc.Param, chkArray bool) *Schema {
 	return s
 }
 
+// 将 p.OneOf 或 p.AnyOf 转换成 Schema.OneOf 或 Schema.AnyOf
+func newUnionSchema(p *doc.Param) *Schema {
+	s := &Schema{
+		Title:       p.Summary,
+		Description: p.Description.Text,
+		Deprecated:  p.Deprecated != "",
+		XML:         newXML(p),
+	}
+
+	u := p.OneOf
+	if u == nil {
+		u = p.AnyOf
+	}
+
+	schemas := make([]*Schema, 0, len(u.Items))
+	for _, item := range u.Items {
+		schemas = append(schemas, newSchema(item, true))
+	}
+
+	if p.OneOf != nil {
+		s.OneOf = schemas
+	} else {
+		s.AnyOf = schemas
+	}
+
+	if u.Discriminator != "" {
+		s.Discriminator = &Discriminator{PropertyName: u.Discriminator}
+
+		// 只有引用了 doc.Types 的子类型才能通过 mapping 指定其对应的值
+		for _, item := range u.Items {
+			if item.Reference == "" {
+				continue
+			}
+
+			if s.Discriminator.Mapping == nil {
+				s.Discriminator.Mapping = make(map[string]string, len(u.Items))
+			}
+			s.Discriminator.Mapping[item.Name] = schemaRefPrefix + strings.TrimPrefix(item.Reference, "#")
+		}
+	}
+
+	return s
+}
+
 // chkArray 是否需要检测当前类型是否为数组
 func newSchemaFromRequest(p *doc.Request, chkArray bool) *Schema {
 	return newSchema(p.Param(), chkArray)
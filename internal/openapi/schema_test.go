@@ -65,7 +65,109 @@ func TestNewSchema(t *testing.T) {
 	a.Equal(output.Type, "").
 		Equal(len(input.Items), len(output.Properties)).
 		Equal(output.Properties["p1"].Type, TypeString).
-		Equal(output.Properties["p2"].Type, TypeInt)
+		Equal(output.Properties["p2"].Type, TypeNumber)
 
 	a.NotError(output.sanitize())
+
+	// 约束条件
+	min := 1.0
+	max := 100.0
+	input = &doc.Param{
+		Type:  doc.Object,
+		Array: true,
+		Constraint: doc.Constraint{
+			MinItems: 1,
+			MaxItems: 10,
+		},
+		Items: []*doc.Param{
+			{
+				Name:       "id",
+				Type:       doc.Integer,
+				Constraint: doc.Constraint{Min: &min, Max: &max},
+			},
+			{
+				Name: "name",
+				Type: doc.String,
+				Constraint: doc.Constraint{
+					Format:    doc.FormatEmail,
+					MinLength: 5,
+					MaxLength: 50,
+					Pattern:   "^.+@example.com$",
+				},
+			},
+			{
+				Name: "price",
+				Type: doc.Float,
+			},
+		},
+	}
+	output = newSchema(input, true)
+	a.Equal(output.Type, TypeArray).
+		Equal(output.MinItems, 1).
+		Equal(output.MaxItems, 10)
+	id := output.Items.Properties["id"]
+	a.Equal(id.Type, TypeInt).
+		Equal(*id.Minimum, 1).
+		Equal(*id.Maximum, 100)
+	name := output.Items.Properties["name"]
+	a.Equal(name.Type, TypeString).
+		Equal(name.Format, doc.FormatEmail).
+		Equal(name.MinLength, 5).
+		Equal(name.MaxLength, 50).
+		Equal(name.Pattern, "^.+@example.com$")
+	a.Equal(output.Items.Properties["price"].Type, TypeNumber)
+
+	// nullable、readonly 和 writeonly
+	input = &doc.Param{
+		Type: doc.Object,
+		Items: []*doc.Param{
+			{Name: "id", Type: doc.Integer, ReadOnly: true},
+			{Name: "password", Type: doc.String, WriteOnly: true},
+			{Name: "tags", Type: doc.String, Array: true, Nullable: true},
+			{Name: "group", Reference: "group", ReadOnly: true},
+		},
+	}
+	output = newSchema(input, true)
+	a.True(output.Properties["id"].ReadOnly).
+		True(output.Properties["password"].WriteOnly)
+	tags := output.Properties["tags"]
+	a.True(tags.Nullable).False(tags.Items.Nullable)
+	group := output.Properties["group"]
+	a.True(group.ReadOnly).
+		Empty(group.Ref).
+		Equal(group.AllOf[0].Ref, schemaRefPrefix+"group")
+
+	// oneOf 和 anyOf
+	input = &doc.Param{
+		Name: "payment",
+		OneOf: &doc.Union{
+			Discriminator: "type",
+			Items: []*doc.Param{
+				{Name: "card", Reference: "card", Type: doc.Object},
+				{Name: "bank", Type: doc.Object, Items: []*doc.Param{{Name: "type", Type: doc.String}}},
+			},
+		},
+	}
+	output = newSchema(input, true)
+	a.Equal(2, len(output.OneOf)).
+		Nil(output.AnyOf).
+		Equal(output.OneOf[0].Ref, schemaRefPrefix+"card").
+		Equal(output.Discriminator.PropertyName, "type").
+		Equal(output.Discriminator.Mapping, map[string]string{"card": schemaRefPrefix + "card"})
+
+	input.AnyOf, input.OneOf = input.OneOf, nil
+	input.AnyOf.Discriminator = ""
+	output = newSchema(input, true)
+	a.Equal(2, len(output.AnyOf)).Nil(output.Discriminator)
+
+	// map
+	input = &doc.Param{
+		Name:  "titles",
+		Type:  doc.Map,
+		Items: []*doc.Param{{Name: "locale", Type: doc.String}},
+	}
+	output = newSchema(input, true)
+	a.Equal(output.Type, TypeObject).
+		Empty(output.Properties).
+		Equal(output.AdditionalProperties.Type, TypeString)
 }
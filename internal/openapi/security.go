@@ -2,6 +2,8 @@
 
 package openapi
 
+import "github.com/caixw/apidoc/v6/doc"
+
 // SecurityScheme.IN 的可选值
 const (
 	SecurityInQuery  = "query"
@@ -11,7 +13,7 @@ const (
 
 // Security.Type 的可选值
 const (
-	SecurityTypeAPIKey        = "apikey"
+	SecurityTypeAPIKey        = "apiKey"
 	SecurityTypeHTTP          = "http"
 	SecurityTypeOAuth2        = "oauth2"
 	SecurityTypeOpenIDConnect = "openIdConnect"
@@ -28,12 +30,12 @@ type SecurityRequirement map[string][]string
 type SecurityScheme struct {
 	Type             string      `json:"type" yaml:"type"`
 	Description      string      `json:"description,omitempty" yaml:"description,omitempty"`
-	Name             string      `json:"name" yaml:"name"` // 报头或是 cookie 的名称
-	IN               string      `json:"in" yaml:"in"`     // 位置, header, query 和 cookie
-	Scheme           string      `json:"scheme" yaml:"scheme"`
+	Name             string      `json:"name,omitempty" yaml:"name,omitempty"` // 报头或是 cookie 的名称
+	IN               string      `json:"in,omitempty" yaml:"in,omitempty"`     // 位置, header, query 和 cookie
+	Scheme           string      `json:"scheme,omitempty" yaml:"scheme,omitempty"`
 	BearerFormat     string      `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
-	Flows            *OAuthFlows `json:"flows" yaml:"flows"`
-	OpenIDConnectURL string      `json:"openIdConnectUrl" yaml:"openIdConnectUrl"`
+	Flows            *OAuthFlows `json:"flows,omitempty" yaml:"flows,omitempty"`
+	OpenIDConnectURL string      `json:"openIdConnectUrl,omitempty" yaml:"openIdConnectUrl,omitempty"`
 
 	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 }
@@ -53,3 +55,67 @@ type OAuthFlow struct {
 	RefreshURL       string            `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
 	Scopes           map[string]string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
 }
+
+func newSecurityScheme(s *doc.Security) *SecurityScheme {
+	scheme := &SecurityScheme{
+		Description: getDescription(s.Description.Text, s.Summary),
+	}
+
+	switch s.Type {
+	case doc.SecurityAPIKey:
+		scheme.Type = SecurityTypeAPIKey
+		scheme.IN = s.IN
+		scheme.Name = s.Key
+	case doc.SecurityHTTP:
+		scheme.Type = SecurityTypeHTTP
+		scheme.Scheme = s.Scheme
+		scheme.BearerFormat = s.BearerFormat
+	case doc.SecurityOAuth2:
+		scheme.Type = SecurityTypeOAuth2
+		scheme.Flows = &OAuthFlows{}
+		for _, flow := range s.Flows {
+			f := &OAuthFlow{
+				AuthorizationURL: flow.AuthorizationURL,
+				TokenURL:         flow.TokenURL,
+				RefreshURL:       flow.RefreshURL,
+				Scopes:           make(map[string]string, len(flow.Scopes)),
+			}
+			for _, scope := range flow.Scopes {
+				f.Scopes[scope.Name] = scope.Summary
+			}
+
+			switch flow.Type {
+			case doc.FlowImplicit:
+				scheme.Flows.Implicit = f
+			case doc.FlowPassword:
+				scheme.Flows.Password = f
+			case doc.FlowClientCredentials:
+				scheme.Flows.ClientCredentials = f
+			case doc.FlowAuthorizationCode:
+				scheme.Flows.AuthorizationCode = f
+			}
+		}
+	case doc.SecurityOpenIDConnect:
+		scheme.Type = SecurityTypeOpenIDConnect
+		scheme.OpenIDConnectURL = s.URL
+	}
+
+	return scheme
+}
+
+// 将 api 中的 security 转换成 SecurityRequirement 列表
+func newSecurityRequirements(requirements []*doc.SecurityRequirement) []*SecurityRequirement {
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	ret := make([]*SecurityRequirement, 0, len(requirements))
+	for _, req := range requirements {
+		scopes := req.Scopes
+		if scopes == nil { // 不能输出为 null
+			scopes = []string{}
+		}
+		ret = append(ret, &SecurityRequirement{req.Name: scopes})
+	}
+	return ret
+}
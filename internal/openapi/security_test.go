@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+)
+
+func TestNewSecurityScheme(t *testing.T) {
+	a := assert.New(t)
+
+	s := newSecurityScheme(&doc.Security{
+		Name:    "token",
+		Type:    doc.SecurityAPIKey,
+		Summary: "token",
+		IN:      doc.SecurityInHeader,
+		Key:     "X-Token",
+	})
+	a.Equal(s, &SecurityScheme{
+		Type:        SecurityTypeAPIKey,
+		Description: "token",
+		IN:          SecurityInHeader,
+		Name:        "X-Token",
+	})
+
+	s = newSecurityScheme(&doc.Security{
+		Name:         "jwt",
+		Type:         doc.SecurityHTTP,
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+	})
+	a.Equal(s, &SecurityScheme{
+		Type:         SecurityTypeHTTP,
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+	})
+
+	s = newSecurityScheme(&doc.Security{
+		Name: "oauth",
+		Type: doc.SecurityOAuth2,
+		Flows: []*doc.OAuthFlow{
+			{
+				Type:     doc.FlowPassword,
+				TokenURL: "https://example.com/token",
+				Scopes:   []*doc.Scope{{Name: "read", Summary: "read"}},
+			},
+		},
+	})
+	a.Equal(s.Type, SecurityTypeOAuth2).
+		Nil(s.Flows.Implicit).
+		Equal(s.Flows.Password.TokenURL, "https://example.com/token").
+		Equal(s.Flows.Password.Scopes, map[string]string{"read": "read"})
+
+	s = newSecurityScheme(&doc.Security{
+		Name: "oidc",
+		Type: doc.SecurityOpenIDConnect,
+		URL:  "https://example.com",
+	})
+	a.Equal(s, &SecurityScheme{
+		Type:             SecurityTypeOpenIDConnect,
+		OpenIDConnectURL: "https://example.com",
+	})
+}
+
+func TestNewSecurityRequirements(t *testing.T) {
+	a := assert.New(t)
+
+	a.Nil(newSecurityRequirements(nil))
+
+	reqs := newSecurityRequirements([]*doc.SecurityRequirement{
+		{Name: "token"},
+		{Name: "oauth", Scopes: []string{"read"}},
+	})
+	data, err := json.Marshal(reqs)
+	a.NotError(err).Equal(string(data), `[{"token":[]},{"oauth":["read"]}]`)
+}
@@ -0,0 +1,293 @@
+// SPDX-License-Identifier: MIT
+
+package postman
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/internal/render"
+)
+
+type converter struct {
+	doc    *doc.Doc
+	langID string
+}
+
+// JSON 输出 JSON 格式数据
+func JSON(d *doc.Doc) ([]byte, error) {
+	collection, err := convert(d)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(collection, "", "\t")
+}
+
+// 将 doc.Doc 转换成 Collection
+//
+// 每个标签对应一个目录，同一个 API 有多个标签时，会出现在每一个目录中；
+// 没有标签的 API 直接放在顶层。每个服务对应一个同名的变量，作为请求地址的前缀。
+func convert(d *doc.Doc) (*Collection, error) {
+	c := &converter{doc: d, langID: render.LangID(d)}
+
+	collection := &Collection{
+		Info: &Info{
+			Name:        d.Title,
+			Description: newDescription(d.Description),
+			Schema:      SchemaURL,
+			Version:     string(d.Version),
+		},
+		Item:     make([]*Item, 0, len(d.Tags)+len(d.Apis)),
+		Variable: make([]*KeyValue, 0, len(d.Servers)),
+	}
+
+	for _, srv := range d.Servers {
+		collection.Variable = append(collection.Variable, &KeyValue{
+			Key:         srv.Name,
+			Value:       srv.URL,
+			Description: srv.Summary,
+		})
+	}
+
+	for _, g := range render.Groups(d) {
+		if g.Tag == nil { // 未分类的直接放在顶层
+			for _, api := range g.APIs {
+				item, err := c.item(api)
+				if err != nil {
+					return nil, err
+				}
+				collection.Item = append(collection.Item, item)
+			}
+			continue
+		}
+
+		folder := &Item{Name: g.Tag.Title, Item: make([]*Item, 0, len(g.APIs))}
+		for _, api := range g.APIs {
+			item, err := c.item(api)
+			if err != nil {
+				return nil, err
+			}
+			folder.Item = append(folder.Item, item)
+		}
+		collection.Item = append(collection.Item, folder)
+	}
+
+	return collection, nil
+}
+
+func (c *converter) item(api *doc.API) (*Item, error) {
+	name := api.Summary
+	if name == "" {
+		name = string(api.Method) + " " + api.Path.Path
+	}
+
+	desc := newDescription(api.Description)
+	if api.Deprecated != "" {
+		deprecated := locale.Translate(c.langID, locale.OutputDeprecated, api.Deprecated)
+		if desc == nil {
+			desc = &Description{Content: deprecated}
+		} else {
+			desc.Content = deprecated + "\n\n" + desc.Content
+		}
+	}
+
+	req := &Request{
+		Method:      string(api.Method),
+		Header:      make([]*KeyValue, 0, len(api.Headers)+1),
+		URL:         c.url(api),
+		Description: desc,
+	}
+	req.Header = appendParams(req.Header, api.Headers)
+
+	if len(api.Requests) > 0 { // 只能有一个报文，以第一个为准。
+		r := api.Requests[0]
+		mimetype := c.mimetype(r)
+		req.Header = appendParams(req.Header, r.Headers)
+
+		raw, lang, err := c.body(r, mimetype, true)
+		if err != nil {
+			return nil, err
+		}
+		if raw != "" {
+			req.Header = append(req.Header, &KeyValue{Key: "Content-Type", Value: mimetype})
+			req.Body = &Body{Mode: "raw", Raw: raw, Options: &BodyOptions{}}
+			req.Body.Options.Raw.Language = lang
+		}
+	}
+
+	item := &Item{Name: name, Request: req}
+	for _, resp := range api.Responses {
+		r, err := c.response(resp)
+		if err != nil {
+			return nil, err
+		}
+		item.Response = append(item.Response, r)
+	}
+	for _, resp := range c.doc.Responses {
+		r, err := c.response(resp)
+		if err != nil {
+			return nil, err
+		}
+		item.Response = append(item.Response, r)
+	}
+	return item, nil
+}
+
+// 生成请求地址，路径参数 {id} 会被转换成 Postman 的 :id 格式。
+func (c *converter) url(api *doc.API) *URL {
+	u := &URL{}
+
+	if len(api.Servers) > 0 { // 多个服务时，以第一个为准。
+		u.Host = []string{"{{" + api.Servers[0] + "}}"}
+	}
+
+	for _, seg := range strings.Split(strings.Trim(api.Path.Path, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			seg = ":" + seg[1:len(seg)-1]
+		}
+		u.Path = append(u.Path, seg)
+	}
+
+	for _, p := range api.Path.Params {
+		u.Variable = append(u.Variable, newKeyValue(p))
+	}
+	u.Query = appendParams(nil, api.Path.Queries)
+
+	u.Raw = strings.Join(u.Host, "") + "/" + strings.Join(u.Path, "/")
+	query := make([]string, 0, len(u.Query))
+	for _, q := range u.Query {
+		if !q.Disabled {
+			query = append(query, q.Key+"="+q.Value)
+		}
+	}
+	if len(query) > 0 {
+		u.Raw += "?" + strings.Join(query, "&")
+	}
+
+	return u
+}
+
+func (c *converter) response(resp *doc.Request) (*Response, error) {
+	status := int(resp.Status)
+	name := strconv.Itoa(status)
+	if resp.Summary != "" {
+		name += " " + resp.Summary
+	}
+
+	r := &Response{
+		Name:   name,
+		Code:   status,
+		Status: http.StatusText(status),
+		Header: appendParams(nil, resp.Headers),
+	}
+
+	mimetype := c.mimetype(resp)
+	raw, lang, err := c.body(resp, mimetype, false)
+	if err != nil {
+		return nil, err
+	}
+	if raw != "" {
+		r.Header = append(r.Header, &KeyValue{Key: "Content-Type", Value: mimetype})
+		r.Body = raw
+		r.PreviewLanguage = lang
+	}
+
+	return r, nil
+}
+
+// 报文的 mimetype
+//
+// 未指定 mimetype 的，依次采用第一个示例代码的 mimetype、
+// 文档中的第一个 JSON 格式的 mimetype 以及文档的第一个 mimetype。
+func (c *converter) mimetype(r *doc.Request) string {
+	if r.Mimetype != "" {
+		return r.Mimetype
+	}
+
+	if len(r.Examples) > 0 && r.Examples[0].Mimetype != "" {
+		return r.Examples[0].Mimetype
+	}
+
+	for _, mimetype := range c.doc.Mimetypes {
+		if language(mimetype) == "json" {
+			return mimetype
+		}
+	}
+
+	if len(c.doc.Mimetypes) > 0 {
+		return c.doc.Mimetypes[0]
+	}
+	return ""
+}
+
+// 返回报文的内容以及其语言
+//
+// 优先使用 mimetype 相同的示例代码，没有示例代码的 JSON 格式报文，则根据参数生成。
+func (c *converter) body(r *doc.Request, mimetype string, request bool) (raw, lang string, err error) {
+	lang = language(mimetype)
+
+	for _, exp := range r.Examples {
+		if exp.Mimetype == mimetype || exp.Mimetype == "" {
+			return strings.TrimSpace(exp.Content), lang, nil
+		}
+	}
+
+	if lang == "json" && (r.Type != doc.None || r.IsUnion()) {
+		data, err := json.MarshalIndent(newValue(r.Param(), request, true), "", "\t")
+		if err != nil {
+			return "", "", err
+		}
+		return string(data), lang, nil
+	}
+
+	return "", lang, nil
+}
+
+func language(mimetype string) string {
+	switch {
+	case strings.HasSuffix(mimetype, "json"):
+		return "json"
+	case strings.HasSuffix(mimetype, "xml"):
+		return "xml"
+	case strings.HasPrefix(mimetype, "text/html"):
+		return "html"
+	default:
+		return "text"
+	}
+}
+
+func appendParams(kv []*KeyValue, params []*doc.Param) []*KeyValue {
+	for _, p := range params {
+		kv = append(kv, newKeyValue(p))
+	}
+	return kv
+}
+
+// 可选的参数默认为禁用状态
+func newKeyValue(p *doc.Param) *KeyValue {
+	return &KeyValue{
+		Key:         p.Name,
+		Value:       simpleValue(p),
+		Description: p.Summary,
+		Disabled:    p.Optional,
+	}
+}
+
+func newDescription(text doc.Richtext) *Description {
+	if text.Text == "" {
+		return nil
+	}
+
+	typ := "text/markdown"
+	if text.Type == doc.RichtextTypeHTML {
+		typ = "text/html"
+	}
+	return &Description{Content: strings.TrimSpace(text.Text), Type: typ}
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+
+package postman
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/doc/doctest"
+)
+
+func TestJSON(t *testing.T) {
+	a := assert.New(t)
+
+	data, err := JSON(doctest.Get())
+	a.NotError(err).NotEmpty(data)
+
+	c := &Collection{}
+	a.NotError(json.Unmarshal(data, c))
+	a.Equal(c.Info.Name, "test").
+		Equal(c.Info.Version, "1.0.1").
+		Equal(c.Info.Schema, SchemaURL).
+		Equal(c.Info.Description.Type, "text/html")
+
+	a.Equal(len(c.Variable), 2).
+		Equal(c.Variable[0].Key, "admin").
+		Equal(c.Variable[0].Value, "https://example.com/admin")
+
+	a.Equal(len(c.Item), 3) // t1,t2,tag1
+	t1 := c.Item[0]
+	a.Equal(t1.Name, "t1").Nil(t1.Request).Equal(len(t1.Item), 2)
+
+	get := t1.Item[0]
+	a.Equal(get.Name, "GET /users").
+		Equal(get.Request.Method, "GET").
+		Equal(get.Request.URL.Raw, "{{admin}}/users").
+		Equal(get.Request.URL.Host, []string{"{{admin}}"}).
+		Equal(get.Request.Body.Raw, "xxx").
+		Equal(get.Request.Body.Options.Raw.Language, "json")
+	a.Equal(len(get.Response), 1).
+		Equal(get.Response[0].Code, 200).
+		Equal(get.Response[0].Status, "OK").
+		Equal(get.Response[0].Body, "xxx")
+
+	post := t1.Item[1]
+	a.Equal(post.Name, "summary").
+		NotNil(post.Request.Description).
+		Nil(post.Request.Body)
+}
+
+func TestConverter_url(t *testing.T) {
+	a := assert.New(t)
+
+	c := &converter{doc: &doc.Doc{}, langID: "und"}
+	u := c.url(&doc.API{
+		Servers: []string{"admin"},
+		Path: &doc.Path{
+			Path:   "/users/{id}/logs",
+			Params: []*doc.Param{{Name: "id", Type: doc.Number}},
+			Queries: []*doc.Param{
+				{Name: "page", Type: doc.Number, Default: "1"},
+				{Name: "size", Type: doc.Number, Optional: true},
+				{Name: "sort", Type: doc.String, Enums: []*doc.Enum{{Value: "asc"}, {Value: "desc"}}},
+			},
+		},
+	})
+	a.Equal(u.Raw, "{{admin}}/users/:id/logs?page=1&sort=asc").
+		Equal(u.Path, []string{"users", ":id", "logs"}).
+		Equal(len(u.Variable), 1).
+		Equal(u.Variable[0].Key, "id").
+		Equal(len(u.Query), 3).
+		True(u.Query[1].Disabled)
+}
+
+func TestNewValue(t *testing.T) {
+	a := assert.New(t)
+
+	p := &doc.Param{
+		Type:  doc.Object,
+		Array: true,
+		Items: []*doc.Param{
+			{Name: "id", Type: doc.Number, ReadOnly: true},
+			{Name: "name", Type: doc.String, Default: "n"},
+			{Name: "password", Type: doc.String, WriteOnly: true},
+			{Name: "admin", Type: doc.Bool, Default: "true"},
+			{Name: "tags", Type: doc.Map, Items: []*doc.Param{{Type: doc.Integer, Enums: []*doc.Enum{{Value: "5"}}}}},
+		},
+	}
+
+	data, err := json.Marshal(newValue(p, true, true))
+	a.NotError(err).
+		Equal(string(data), `[{"name":"n","password":"","admin":true,"tags":{"key":5}}]`)
+
+	data, err = json.Marshal(newValue(p, false, true))
+	a.NotError(err).
+		Equal(string(data), `[{"id":0,"name":"n","admin":true,"tags":{"key":5}}]`)
+
+	p = &doc.Param{OneOf: &doc.Union{Items: []*doc.Param{{Type: doc.String, Default: "one"}}}}
+	data, err = json.Marshal(newValue(p, true, true))
+	a.NotError(err).Equal(string(data), `"one"`)
+
+	// JSON 不支持的数值格式
+	for _, v := range []string{"Inf", "NaN", "0x10", "1e500", `"1"`} {
+		p = &doc.Param{Type: doc.Number, Default: v}
+		data, err = json.Marshal(newValue(p, true, true))
+		a.NotError(err).Equal(string(data), "0", v)
+	}
+	p = &doc.Param{Type: doc.Float, Default: " -1.5e2 "}
+	data, err = json.Marshal(newValue(p, true, true))
+	a.NotError(err).Equal(string(data), "-1.5e2")
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+
+// Package postman 输出 Postman collection v2.1 格式的文档
+//
+// https://schema.getpostman.com/json/collection/v2.1.0/collection.json
+package postman
+
+// SchemaURL collection v2.1 的 JSON Schema 地址
+const SchemaURL = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// Collection 文档的顶层对象
+type Collection struct {
+	Info     *Info       `json:"info"`
+	Item     []*Item     `json:"item"`
+	Variable []*KeyValue `json:"variable,omitempty"`
+}
+
+// Info 文档的基本信息
+type Info struct {
+	Name        string       `json:"name"`
+	Description *Description `json:"description,omitempty"`
+	Schema      string       `json:"schema"`
+	Version     string       `json:"version,omitempty"`
+}
+
+// Description 描述信息
+type Description struct {
+	Content string `json:"content"`
+	Type    string `json:"type,omitempty"` // text/markdown 或是 text/html
+}
+
+// Item 表示一个请求或是包含多个请求的目录
+//
+// Request 为空表示是目录，否则为请求。
+type Item struct {
+	Name        string       `json:"name"`
+	Description *Description `json:"description,omitempty"`
+
+	// 目录
+	Item []*Item `json:"item,omitempty"`
+
+	// 请求
+	Request  *Request    `json:"request,omitempty"`
+	Response []*Response `json:"response,omitempty"`
+}
+
+// Request 请求的内容
+type Request struct {
+	Method      string       `json:"method"`
+	Header      []*KeyValue  `json:"header"`
+	URL         *URL         `json:"url"`
+	Body        *Body        `json:"body,omitempty"`
+	Description *Description `json:"description,omitempty"`
+}
+
+// URL 请求地址
+type URL struct {
+	Raw      string      `json:"raw"`
+	Host     []string    `json:"host,omitempty"`
+	Path     []string    `json:"path,omitempty"`
+	Query    []*KeyValue `json:"query,omitempty"`
+	Variable []*KeyValue `json:"variable,omitempty"`
+}
+
+// KeyValue 报头、查询参数以及变量等键值对
+type KeyValue struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// Body 请求的报文
+type Body struct {
+	Mode    string       `json:"mode"` // 固定为 raw
+	Raw     string       `json:"raw"`
+	Options *BodyOptions `json:"options,omitempty"`
+}
+
+// BodyOptions 报文的选项
+type BodyOptions struct {
+	Raw struct {
+		Language string `json:"language"` // json、xml 等
+	} `json:"raw"`
+}
+
+// Response 保存的返回示例
+type Response struct {
+	Name            string      `json:"name"`
+	Code            int         `json:"code"`
+	Status          string      `json:"status,omitempty"`
+	Header          []*KeyValue `json:"header,omitempty"`
+	Body            string      `json:"body,omitempty"`
+	PreviewLanguage string      `json:"_postman_previewlanguage,omitempty"`
+}
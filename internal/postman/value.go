@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+
+package postman
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/caixw/apidoc/v6/doc"
+)
+
+// 保持字段顺序的 JSON 对象
+type object []*field
+
+type field struct {
+	name  string
+	value interface{}
+}
+
+// MarshalJSON json.Marshaler
+func (o object) MarshalJSON() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte('{')
+	for index, f := range o {
+		if index > 0 {
+			buf.WriteByte(',')
+		}
+
+		name, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// 根据参数生成示例数据
+//
+// 与 mock 不同，生成的值是固定的：依次采用默认值、第一个枚举值以及类型的零值，
+// 以保证每次生成的文档内容相同。
+// request 表示是否为请求数据，请求中不包含只读的字段，返回中不包含只写的字段。
+func newValue(p *doc.Param, request, chkArray bool) interface{} {
+	if p == nil {
+		return nil
+	}
+
+	if p.Array && chkArray {
+		return []interface{}{newValue(p, request, false)}
+	}
+
+	if p.IsUnion() { // 以第一个子类型为准
+		u := p.OneOf
+		if u == nil {
+			u = p.AnyOf
+		}
+		if len(u.Items) == 0 {
+			return nil
+		}
+		return newValue(u.Items[0], request, true)
+	}
+
+	switch p.Type {
+	case doc.Bool:
+		v, _ := strconv.ParseBool(simpleValue(p))
+		return v
+	case doc.Number, doc.Integer, doc.Float:
+		// Inf、NaN 和十六进制等 JSON 不支持的格式，以 0 代替。
+		v := strings.TrimSpace(simpleValue(p))
+		if err := json.Unmarshal([]byte(v), new(float64)); err != nil {
+			return 0
+		}
+		return json.Number(v)
+	case doc.String:
+		return simpleValue(p)
+	case doc.Object:
+		obj := make(object, 0, len(p.Items))
+		for _, item := range p.Items {
+			if (request && item.ReadOnly) || (!request && item.WriteOnly) {
+				continue
+			}
+			obj = append(obj, &field{name: item.Name, value: newValue(item, request, true)})
+		}
+		return obj
+	case doc.Map:
+		return object{{name: "key", value: newValue(p.MapValue(), request, true)}}
+	default: // doc.None
+		return nil
+	}
+}
+
+// 参数的值，依次采用默认值和第一个枚举值。
+func simpleValue(p *doc.Param) string {
+	if p.Default != "" {
+		return p.Default
+	}
+	if len(p.Enums) > 0 {
+		return p.Enums[0].Value
+	}
+	return ""
+}
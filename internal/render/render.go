@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+
+// Package render 提供 markdown、html 和 postman 等输出格式共用的函数
+package render
+
+import "github.com/caixw/apidoc/v6/doc"
+
+// Group 按标签分组的 API
+type Group struct {
+	Tag  *doc.Tag // 为空表示未分类
+	APIs []*doc.API
+}
+
+// Groups 按 d.Tags 的顺序对 API 进行分组
+//
+// 同一个 API 有多个标签时，会出现在每一个分组中；没有 API 的标签会被忽略。
+// 没有标签或是标签不存在于 d.Tags 中的 API，统一归类到最后一个 Tag 为空的分组中。
+func Groups(d *doc.Doc) []*Group {
+	groups := make([]*Group, 0, len(d.Tags)+1)
+	tagged := make(map[*doc.API]bool, len(d.Apis))
+
+	for _, tag := range d.Tags {
+		g := &Group{Tag: tag}
+		for _, api := range d.Apis {
+			if containsTag(api, tag.Name) {
+				g.APIs = append(g.APIs, api)
+				tagged[api] = true
+			}
+		}
+		if len(g.APIs) > 0 {
+			groups = append(groups, g)
+		}
+	}
+
+	untagged := &Group{}
+	for _, api := range d.Apis {
+		if !tagged[api] {
+			untagged.APIs = append(untagged.APIs, api)
+		}
+	}
+	if len(untagged.APIs) > 0 {
+		groups = append(groups, untagged)
+	}
+
+	return groups
+}
+
+func containsTag(api *doc.API, tag string) bool {
+	for _, t := range api.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// LangID 输出内容时采用的语言 ID
+//
+// 文档未指定 lang 时，返回 und，表示采用当前系统的本地化信息。
+func LangID(d *doc.Doc) string {
+	if d.Lang == "" {
+		return "und"
+	}
+	return d.Lang
+}
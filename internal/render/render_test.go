@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT
+
+package render
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+)
+
+func TestGroups(t *testing.T) {
+	a := assert.New(t)
+
+	t1 := &doc.Tag{Name: "t1", Title: "T1"}
+	t2 := &doc.Tag{Name: "t2", Title: "T2"}
+	t3 := &doc.Tag{Name: "t3", Title: "T3"}
+	api1 := &doc.API{Tags: []string{"t1", "t2"}}
+	api2 := &doc.API{Tags: []string{"t2"}}
+	api3 := &doc.API{}
+	api4 := &doc.API{Tags: []string{"not-exists"}}
+
+	groups := Groups(&doc.Doc{Tags: []*doc.Tag{t1, t2, t3}, Apis: []*doc.API{api1, api2, api3, api4}})
+	a.Equal(len(groups), 3)
+	a.Equal(groups[0].Tag, t1).Equal(groups[0].APIs, []*doc.API{api1})
+	a.Equal(groups[1].Tag, t2).Equal(groups[1].APIs, []*doc.API{api1, api2})
+	a.Nil(groups[2].Tag).Equal(groups[2].APIs, []*doc.API{api3, api4})
+
+	groups = Groups(&doc.Doc{Tags: []*doc.Tag{t1}, Apis: []*doc.API{api1}})
+	a.Equal(len(groups), 1).Equal(groups[0].Tag, t1)
+}
+
+func TestLangID(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(LangID(&doc.Doc{}), "und")
+	a.Equal(LangID(&doc.Doc{Lang: "zh-Hant"}), "zh-Hant")
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+
+// Package lint 对文档内容进行风格上的检测
+//
+// 与 doc 包中的语法检测不同，此处的规则都不影响文档的正确性，
+// 可以通过 Options 启用或是禁用某一条规则，以及调整其输出的级别。
+package lint
+
+import (
+	"sort"
+
+	xmessage "golang.org/x/text/message"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/message"
+)
+
+// 规则可用的级别
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelInfo    = "info"
+	LevelOff     = "off" // 禁用该规则
+)
+
+// Options lint 的配置项
+type Options struct {
+	// 指定规则的级别
+	//
+	// 键名为规则的名称，键值为 error、warning、info 或是 off，
+	// 未指定的规则采用其默认的级别。
+	Rules map[string]string `yaml:"rules,omitempty"`
+
+	types map[string]message.Type // 启用的规则及其级别
+}
+
+func (o *Options) sanitize() *message.SyntaxError {
+	o.types = make(map[string]message.Type, len(rules))
+	for _, r := range rules {
+		o.types[r.name] = r.typ
+	}
+
+	names := make([]string, 0, len(o.Rules))
+	for name := range o.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := "rules." + name
+
+		if _, found := o.types[name]; !found {
+			return message.NewLocaleError("", field, 0, locale.ErrNotFound)
+		}
+
+		switch o.Rules[name] {
+		case LevelError:
+			o.types[name] = message.Erro
+		case LevelWarning:
+			o.types[name] = message.Warn
+		case LevelInfo:
+			o.types[name] = message.Info
+		case LevelOff:
+			delete(o.types, name)
+		default:
+			return message.NewLocaleError("", field, 0, locale.ErrInvalidValue)
+		}
+	}
+
+	return nil
+}
+
+// 报告规则检测到的问题
+type reporter func(file, field string, line int, key xmessage.Reference, v ...interface{})
+
+// Check 根据 o 指定的规则检测 d 的内容
+//
+// 检测到的问题以 *message.SyntaxError 的形式输出到 h，错误代码即为规则的名称；
+// 如果是 o 本身有问题，则返回错误信息。o 为 nil 表示所有规则都采用默认的级别。
+//
+// d 应该是已经通过 Sanitize 检测的文档。
+func Check(h *message.Handler, d *doc.Doc, o *Options) error {
+	if o == nil {
+		o = &Options{}
+	}
+	if err := o.sanitize(); err != nil {
+		return err
+	}
+
+	for _, r := range rules {
+		t, found := o.types[r.name]
+		if !found {
+			continue
+		}
+
+		name := r.name
+		r.check(d, func(file, field string, line int, key xmessage.Reference, v ...interface{}) {
+			err := message.NewLocaleError(file, field, line, key, v...)
+			err.Code = name
+			h.Error(t, err)
+		})
+	}
+
+	return nil
+}
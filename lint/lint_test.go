@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/message"
+)
+
+const testDoc = `<apidoc version="1.1.1">
+	<title>title</title>
+	<tag name="t1" title="t1" />
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<mimetype>application/json</mimetype>
+</apidoc>`
+
+func newDoc(a *assert.Assertion, apis ...string) *doc.Doc {
+	d := doc.New()
+	a.NotError(d.FromXML("doc.xml", 1, []byte(testDoc)))
+	for i, api := range apis {
+		a.NotError(d.NewAPI("api.go", (i+1)*10, []byte(api)))
+	}
+	a.NotError(d.Sanitize())
+	return d
+}
+
+func TestOptions_sanitize(t *testing.T) {
+	a := assert.New(t)
+
+	o := &Options{}
+	a.NotError(o.sanitize())
+	a.Equal(len(o.types), len(rules)).
+		Equal(o.types["api-id"], message.Warn)
+
+	o = &Options{Rules: map[string]string{
+		"api-id":      LevelError,
+		"api-summary": LevelInfo,
+		"api-tag":     LevelOff,
+	}}
+	a.NotError(o.sanitize())
+	a.Equal(len(o.types), len(rules)-1).
+		Equal(o.types["api-id"], message.Erro).
+		Equal(o.types["api-summary"], message.Info)
+	_, found := o.types["api-tag"]
+	a.False(found)
+
+	o = &Options{Rules: map[string]string{"not-exists": LevelError}}
+	err := o.sanitize()
+	a.Error(err).Equal(err.Field, "rules.not-exists")
+
+	o = &Options{Rules: map[string]string{"api-id": "invalid"}}
+	err = o.sanitize()
+	a.Error(err).Equal(err.Field, "rules.api-id")
+}
+
+func TestCheck(t *testing.T) {
+	a := assert.New(t)
+
+	d := newDoc(a, `<api method="GET" summary="summary">
+	<path path="/users" />
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+</api>`)
+
+	errs := make([]*message.SyntaxError, 0, 10)
+	types := make([]message.Type, 0, 10)
+	h := message.NewHandler(func(msg *message.Message) {
+		errs = append(errs, msg.Err)
+		types = append(types, msg.Type)
+	})
+	a.NotError(Check(h, d, &Options{Rules: map[string]string{
+		"api-id":  LevelError,
+		"api-tag": LevelOff,
+	}}))
+	h.Stop()
+
+	a.Equal(2, len(errs))
+	a.Equal(errs[0].Code, "api-id").
+		Equal(errs[0].File, "api.go").
+		Equal(errs[0].Line, 10).
+		Equal(types[0], message.Erro)
+	a.Equal(errs[1].Code, "api-4xx-response").Equal(types[1], message.Warn)
+
+	// 配置项错误
+	h = message.NewHandler(func(*message.Message) {})
+	a.Error(Check(h, d, &Options{Rules: map[string]string{"not-exists": LevelError}}))
+	h.Stop()
+
+	// nil 表示采用默认值
+	h = message.NewHandler(func(*message.Message) {})
+	a.NotError(Check(h, d, nil))
+	h.Stop()
+	a.Equal(3, h.Count(message.Warn))
+}
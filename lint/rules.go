@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"strings"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/message"
+)
+
+// 规则的定义
+type rule struct {
+	name  string
+	typ   message.Type // 默认的级别
+	check func(*doc.Doc, reporter)
+}
+
+// 所有的规则，检测结果按此顺序输出。
+//
+// 规则的名称同时也作为错误代码，一经确定便不能再修改。
+var rules = []*rule{
+	{name: "api-id", typ: message.Warn, check: checkAPIID},
+	{name: "api-summary", typ: message.Warn, check: checkAPISummary},
+	{name: "api-tag", typ: message.Warn, check: checkAPITag},
+	{name: "api-4xx-response", typ: message.Warn, check: checkAPI4XXResponse},
+	{name: "path-kebab-case", typ: message.Warn, check: checkPathKebabCase},
+	{name: "enum-description", typ: message.Warn, check: checkEnumDescription},
+}
+
+// 每个 API 都应该有 id
+func checkAPIID(d *doc.Doc, report reporter) {
+	for _, api := range d.Apis {
+		if api.ID == "" {
+			file, line := api.Position()
+			report(file, "api/@id", line, locale.ErrRequired)
+		}
+	}
+}
+
+// 每个 API 都应该有 summary 或是 description
+func checkAPISummary(d *doc.Doc, report reporter) {
+	for _, api := range d.Apis {
+		if strings.TrimSpace(api.Summary) == "" && strings.TrimSpace(api.Description.Text) == "" {
+			file, line := api.Position()
+			report(file, "api/@summary", line, locale.ErrRequired)
+		}
+	}
+}
+
+// 每个 API 都应该至少关联一个标签
+func checkAPITag(d *doc.Doc, report reporter) {
+	for _, api := range d.Apis {
+		if len(api.Tags) == 0 {
+			file, line := api.Position()
+			report(file, "api/tag", line, locale.ErrRequired)
+		}
+	}
+}
+
+// 每个 API 都应该至少声明一个 4XX 的返回值，doc.Responses 中的也算。
+func checkAPI4XXResponse(d *doc.Doc, report reporter) {
+	if has4XX(d.Responses) {
+		return
+	}
+
+	for _, api := range d.Apis {
+		if !has4XX(api.Responses) {
+			file, line := api.Position()
+			report(file, "api/response", line, locale.LintNo4XXResponse)
+		}
+	}
+}
+
+func has4XX(responses []*doc.Request) bool {
+	for _, resp := range responses {
+		if resp.Status >= 400 && resp.Status < 500 {
+			return true
+		}
+	}
+	return false
+}
+
+// 路径中除参数之外的部分都应该是 kebab-case 格式
+func checkPathKebabCase(d *doc.Doc, report reporter) {
+	for _, api := range d.Apis {
+		if api.Path == nil {
+			continue
+		}
+
+		for _, seg := range strings.Split(api.Path.Path, "/") {
+			if seg == "" || strings.IndexByte(seg, '{') >= 0 {
+				continue
+			}
+
+			if !isKebabCase(seg) {
+				file, line := api.Position()
+				report(file, "api/path/@path", line, locale.LintNotKebabCase, seg)
+				break
+			}
+		}
+	}
+}
+
+// 是否为由小写字母和数字组成，以 - 分隔的字符串
+func isKebabCase(s string) bool {
+	for _, word := range strings.Split(s, "-") {
+		if word == "" {
+			return false
+		}
+
+		for _, r := range word {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// 每个枚举值都应该有描述，且描述不能只是重复枚举值本身。
+func checkEnumDescription(d *doc.Doc, report reporter) {
+	file, line := d.Position()
+	w := &enumWalker{file: file, line: line, report: report}
+	w.params("apidoc/type", d.Types)
+	w.requests("apidoc/response", d.Responses)
+
+	for _, api := range d.Apis {
+		w.file, w.line = api.Position()
+		w.api("api", api)
+	}
+}
+
+type enumWalker struct {
+	file   string
+	line   int
+	report reporter
+}
+
+func (w *enumWalker) api(field string, api *doc.API) {
+	w.path(field+"/path", api.Path)
+	w.params(field+"/header", api.Headers)
+	w.params(field+"/cookie", api.Cookies)
+	w.requests(field+"/request", api.Requests)
+	w.requests(field+"/response", api.Responses)
+
+	if cb := api.Callback; cb != nil {
+		field += "/callback"
+		w.path(field+"/path", cb.Path)
+		w.params(field+"/header", cb.Headers)
+		w.params(field+"/cookie", cb.Cookies)
+		w.requests(field+"/request", cb.Requests)
+		w.requests(field+"/response", cb.Responses)
+	}
+}
+
+func (w *enumWalker) path(field string, p *doc.Path) {
+	if p != nil {
+		w.params(field+"/param", p.Params)
+		w.params(field+"/query", p.Queries)
+	}
+}
+
+func (w *enumWalker) requests(field string, requests []*doc.Request) {
+	for _, r := range requests {
+		w.enums(field, r.Enums)
+		w.params(field+"/param", r.Items)
+		w.union(field, r.OneOf, r.AnyOf)
+		w.params(field+"/header", r.Headers)
+		w.params(field+"/cookie", r.Cookies)
+	}
+}
+
+func (w *enumWalker) params(field string, params []*doc.Param) {
+	for _, p := range params {
+		w.enums(field, p.Enums)
+		w.params(field+"/param", p.Items)
+		w.union(field, p.OneOf, p.AnyOf)
+	}
+}
+
+func (w *enumWalker) union(field string, oneOf, anyOf *doc.Union) {
+	if oneOf != nil {
+		w.params(field+"/one-of/param", oneOf.Items)
+	}
+	if anyOf != nil {
+		w.params(field+"/any-of/param", anyOf.Items)
+	}
+}
+
+func (w *enumWalker) enums(field string, enums []*doc.Enum) {
+	for _, e := range enums {
+		summary := strings.TrimSpace(e.Summary)
+		if strings.TrimSpace(e.Description.Text) == "" && (summary == "" || strings.EqualFold(summary, e.Value)) {
+			w.report(w.file, field+"/enum/@summary", w.line, locale.LintEnumNoDescription, e.Value)
+		}
+	}
+}
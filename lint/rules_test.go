@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+	xmessage "golang.org/x/text/message"
+
+	"github.com/caixw/apidoc/v6/doc"
+)
+
+// 执行 check，并返回所有报告的字段
+func runCheck(d *doc.Doc, check func(*doc.Doc, reporter)) []string {
+	fields := make([]string, 0, 10)
+	check(d, func(file, field string, line int, key xmessage.Reference, v ...interface{}) {
+		fields = append(fields, field)
+	})
+	return fields
+}
+
+func TestCheckAPIID(t *testing.T) {
+	a := assert.New(t)
+
+	d := newDoc(a, `<api method="GET" summary="summary" id="get-users">
+	<path path="/users" />
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+</api>`, `<api method="POST" summary="summary">
+	<path path="/users" />
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+</api>`)
+	a.Equal(runCheck(d, checkAPIID), []string{"api/@id"})
+}
+
+func TestCheckAPISummary(t *testing.T) {
+	a := assert.New(t)
+
+	d := newDoc(a, `<api method="GET">
+	<path path="/users" />
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+</api>`, `<api method="POST">
+	<description>description</description>
+	<path path="/users" />
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+</api>`)
+	a.Equal(runCheck(d, checkAPISummary), []string{"api/@summary"})
+}
+
+func TestCheckAPITag(t *testing.T) {
+	a := assert.New(t)
+
+	d := newDoc(a, `<api method="GET" summary="summary">
+	<path path="/users" />
+	<tag>t1</tag>
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+</api>`, `<api method="POST" summary="summary">
+	<path path="/users" />
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+</api>`)
+	a.Equal(runCheck(d, checkAPITag), []string{"api/tag"})
+}
+
+func TestCheckAPI4XXResponse(t *testing.T) {
+	a := assert.New(t)
+
+	d := newDoc(a, `<api method="GET" summary="summary">
+	<path path="/users" />
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+	<response status="404" type="string" summary="summary" />
+</api>`, `<api method="POST" summary="summary">
+	<path path="/users" />
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+	<response status="500" type="string" summary="summary" />
+</api>`)
+	a.Equal(runCheck(d, checkAPI4XXResponse), []string{"api/response"})
+
+	// doc.Responses 中的对所有 API 都有效
+	d.Responses = append(d.Responses, &doc.Request{Status: 401})
+	a.Empty(runCheck(d, checkAPI4XXResponse))
+}
+
+func TestCheckPathKebabCase(t *testing.T) {
+	a := assert.New(t)
+
+	d := newDoc(a, `<api method="GET" summary="summary">
+	<path path="/v1/user-groups/{groupID}" >
+		<param name="groupID" type="number" summary="id" />
+	</path>
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+</api>`, `<api method="GET" summary="summary">
+	<path path="/v1/userGroups" />
+	<server>admin</server>
+	<response status="200" type="string" summary="summary" />
+</api>`)
+	a.Equal(runCheck(d, checkPathKebabCase), []string{"api/path/@path"})
+}
+
+func TestIsKebabCase(t *testing.T) {
+	a := assert.New(t)
+
+	a.True(isKebabCase("users"))
+	a.True(isKebabCase("user-groups"))
+	a.True(isKebabCase("v1"))
+	a.False(isKebabCase("userGroups"))
+	a.False(isKebabCase("user_groups"))
+	a.False(isKebabCase("-users"))
+	a.False(isKebabCase("user--groups"))
+}
+
+func TestCheckEnumDescription(t *testing.T) {
+	a := assert.New(t)
+
+	d := newDoc(a, `<api method="GET" summary="summary">
+	<path path="/users">
+		<query name="sex" type="string" summary="sex">
+			<enum value="male" summary="male" />
+			<enum value="female" summary="女性" />
+		</query>
+	</path>
+	<server>admin</server>
+	<response status="200" type="object" summary="summary">
+		<param name="state" type="string" summary="state">
+			<enum value="locked" summary="locked" />
+			<enum value="normal"><description>正常</description></enum>
+		</param>
+	</response>
+</api>`)
+	a.Equal(runCheck(d, checkEnumDescription), []string{
+		"api/path/query/enum/@summary",
+		"api/response/param/enum/@summary",
+	})
+}
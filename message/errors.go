@@ -4,6 +4,7 @@ package message
 
 import (
 	"strconv"
+	"strings"
 
 	"golang.org/x/text/message"
 
@@ -19,6 +20,19 @@ type SyntaxError struct {
 	File    string
 	Line    int
 	Field   string
+
+	// 错误在文件中的具体范围
+	//
+	// Line 和 Column 为起始位置，EndLine 和 EndColumn 为结束位置（不包含），
+	// 列号以字节为单位，从 1 开始，为 0 表示未指定列号。
+	Column    int
+	EndLine   int
+	EndColumn int
+
+	// 错误代码，比如 E-REQUIRED
+	//
+	// 与本地化无关，可用于过滤特定的错误，为空表示没有对应的错误代码。
+	Code string
 }
 
 func (err *SyntaxError) Error() string {
@@ -26,6 +40,10 @@ func (err *SyntaxError) Error() string {
 
 	if err.Line > 0 {
 		detail += ":" + strconv.Itoa(err.Line)
+
+		if err.Column > 0 {
+			detail += ":" + strconv.Itoa(err.Column)
+		}
 	}
 
 	if err.Field != "" {
@@ -36,6 +54,20 @@ func (err *SyntaxError) Error() string {
 	return locale.Sprintf(locale.ErrMessage, err.Message, detail)
 }
 
+// SyntaxErrors 多个语法错误的集合
+//
+// 在收集错误的模式下，同一个文档块中的所有错误会以此类型返回。
+// 通过 Handler.Error 输出时，每个错误会作为单独的一条消息发送。
+type SyntaxErrors []*SyntaxError
+
+func (errs SyntaxErrors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
 // NewLocaleError 本地化的错误信息
 //
 // 其中的 msg 和 val 会被转换成本地化的内容保存。
@@ -45,15 +77,24 @@ func NewLocaleError(file, field string, line int, msg message.Reference, val ...
 		File:    file,
 		Line:    line,
 		Field:   field,
+		Code:    locale.ErrorCode(msg),
 	}
 }
 
 // WithError 声明 SyntaxError 实例，其中的提示信息由 err 返回
+//
+// 如果 err 是由 locale.Errorf 生成的，会同时保留其错误代码。
 func WithError(file, field string, line int, err error) *SyntaxError {
-	return &SyntaxError{
+	serr := &SyntaxError{
 		Message: err.Error(),
 		File:    file,
 		Line:    line,
 		Field:   field,
 	}
+
+	if lerr, ok := err.(*locale.Error); ok {
+		serr.Code = locale.ErrorCode(lerr.Key)
+	}
+
+	return serr
 }
@@ -4,12 +4,18 @@ package message
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/internal/locale"
 )
 
-var _ error = &SyntaxError{}
+var (
+	_ error = &SyntaxError{}
+	_ error = SyntaxErrors{}
+)
 
 func TestNewLocaleError(t *testing.T) {
 	a := assert.New(t)
@@ -17,6 +23,13 @@ func TestNewLocaleError(t *testing.T) {
 	err1 := NewLocaleError("file", "", 0, "msg")
 	err2 := NewLocaleError("file", "field", 0, "msg")
 	a.NotEqual(err1.Error(), err2.Error())
+
+	a.Empty(err1.Code)
+	a.Equal(NewLocaleError("file", "", 0, locale.ErrRequired).Code, "E-REQUIRED")
+
+	err1.Line = 2
+	err1.Column = 3
+	a.True(strings.Contains(err1.Error(), "file:2:3"))
 }
 
 func TestWithError(t *testing.T) {
@@ -25,4 +38,17 @@ func TestWithError(t *testing.T) {
 	err := errors.New("test")
 	serr := WithError("file", "field", 1, err)
 	a.Equal(serr.Message, err.Error())
+	a.Empty(serr.Code)
+
+	serr = WithError("file", "field", 1, locale.Errorf(locale.ErrInvalidValue))
+	a.Equal(serr.Code, "E-INVALID-VALUE")
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	a := assert.New(t)
+
+	err1 := NewLocaleError("file1", "", 0, "msg")
+	err2 := NewLocaleError("file2", "", 0, "msg")
+	errs := SyntaxErrors{err1, err2}
+	a.Equal(errs.Error(), err1.Error()+"\n"+err2.Error())
 }
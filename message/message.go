@@ -4,6 +4,8 @@
 package message
 
 import (
+	"sync"
+
 	"golang.org/x/text/message"
 
 	"github.com/caixw/apidoc/v6/internal/locale"
@@ -37,19 +39,50 @@ func (t Type) String() string {
 
 // Message 输出消息的具体结构
 type Message struct {
-	Type    Type
+	Type    Type // 消息的类型，对于错误信息而言，即其严重程度
 	Message string
+
+	// 由 SyntaxError 生成的消息会保留原始的错误信息，
+	// 可以从中获取文件、位置以及错误代码等内容，其它情况下为 nil。
+	Err *SyntaxError
 }
 
 // HandlerFunc 错误处理函数
 type HandlerFunc func(*Message)
 
+// Code 返回消息对应的错误代码
+//
+// 如果不是由 SyntaxError 生成的消息，或是没有错误代码，则返回空值。
+func (msg *Message) Code() string {
+	if msg.Err == nil {
+		return ""
+	}
+	return msg.Err.Code
+}
+
+// Suppress 返回一个新的 HandlerFunc，忽略错误代码在 codes 中的消息
+func Suppress(f HandlerFunc, codes ...string) HandlerFunc {
+	return func(msg *Message) {
+		code := msg.Code()
+		for _, c := range codes {
+			if code != "" && code == c {
+				return
+			}
+		}
+		f(msg)
+	}
+}
+
 // Handler 异步的消息处理机制
 //
 // 包含了本地化的信息，输出时，会以指定的本地化内容输出
 type Handler struct {
 	messages chan *Message
 	stop     chan struct{}
+
+	// 各类消息的数量
+	locker sync.Mutex
+	counts map[Type]int
 }
 
 // NewHandler 声明新的 Handler 实例
@@ -57,6 +90,7 @@ func NewHandler(f HandlerFunc) *Handler {
 	h := &Handler{
 		messages: make(chan *Message, 100),
 		stop:     make(chan struct{}),
+		counts:   make(map[Type]int, 4),
 	}
 
 	go func() {
@@ -80,18 +114,47 @@ func (h *Handler) Stop() {
 	<-h.stop
 }
 
+// Count 返回已经发送的 t 类型消息的数量
+func (h *Handler) Count(t Type) int {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+	return h.counts[t]
+}
+
+func (h *Handler) send(msg *Message) {
+	h.locker.Lock()
+	h.counts[msg.Type]++
+	h.locker.Unlock()
+
+	h.messages <- msg
+}
+
 // Message 发送普通的文本信息，内容由 key 和 val 组成本地化信息
 func (h *Handler) Message(t Type, key message.Reference, val ...interface{}) {
-	h.messages <- &Message{
+	h.send(&Message{
 		Type:    t,
 		Message: locale.Sprintf(key, val...),
-	}
+	})
 }
 
 // Error 将一条错误信息作为消息发送出去
+//
+// 如果 err 为 SyntaxErrors，则其中的每个错误都会作为单独的消息发送。
 func (h *Handler) Error(t Type, err error) {
-	h.messages <- &Message{
+	if errs, ok := err.(SyntaxErrors); ok {
+		for _, e := range errs {
+			h.Error(t, e)
+		}
+		return
+	}
+
+	msg := &Message{
 		Type:    t,
 		Message: err.Error(),
 	}
+	if serr, ok := err.(*SyntaxError); ok {
+		msg.Err = serr
+	}
+
+	h.send(msg)
 }
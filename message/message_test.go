@@ -21,6 +21,10 @@ func TestHandler(t *testing.T) {
 	erro := new(bytes.Buffer)
 	warn := new(bytes.Buffer)
 	h := NewHandler(func(msg *Message) {
+		if msg.Err == nil || msg.Code() != "E-REQUIRED" {
+			panic("丢失了 SyntaxError")
+		}
+
 		switch msg.Type {
 		case Erro:
 			erro.WriteString("erro")
@@ -34,9 +38,13 @@ func TestHandler(t *testing.T) {
 
 	h.Error(Erro, NewLocaleError("erro.go", "", 0, locale.ErrRequired))
 	h.Error(Warn, NewLocaleError("warn.go", "", 0, locale.ErrRequired))
+	h.Error(Erro, SyntaxErrors{ // 每个错误都作为一条单独的消息
+		NewLocaleError("erro1.go", "", 0, locale.ErrRequired),
+		NewLocaleError("erro2.go", "", 0, locale.ErrRequired),
+	})
 
 	time.Sleep(1 * time.Second) // 等待 channel 完成
-	a.Equal(erro.String(), "erro")
+	a.Equal(erro.String(), "erroerroerro")
 	a.Equal(warn.String(), "warn")
 
 	h.Stop()
@@ -45,6 +53,25 @@ func TestHandler(t *testing.T) {
 	})
 }
 
+func TestHandler_Count(t *testing.T) {
+	a := assert.New(t)
+
+	h := NewHandler(func(*Message) {})
+	a.Equal(0, h.Count(Erro))
+
+	h.Error(Erro, NewLocaleError("erro.go", "", 0, locale.ErrRequired))
+	h.Error(Erro, SyntaxErrors{
+		NewLocaleError("erro1.go", "", 0, locale.ErrRequired),
+		NewLocaleError("erro2.go", "", 0, locale.ErrRequired),
+	})
+	h.Message(Warn, locale.ErrRequired)
+	h.Stop()
+
+	a.Equal(3, h.Count(Erro)).
+		Equal(1, h.Count(Warn)).
+		Equal(0, h.Count(Info))
+}
+
 func TestHandler_Stop(t *testing.T) {
 	a := assert.New(t)
 	var exit bool
@@ -59,3 +86,17 @@ func TestHandler_Stop(t *testing.T) {
 	h.Stop() // 此处会阻塞，等待完成
 	a.True(exit)
 }
+
+func TestSuppress(t *testing.T) {
+	a := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	f := Suppress(func(msg *Message) {
+		buf.WriteString(msg.Message)
+	}, "E-REQUIRED")
+
+	f(&Message{Type: Erro, Message: "1", Err: NewLocaleError("", "", 0, locale.ErrRequired)})
+	f(&Message{Type: Erro, Message: "2", Err: NewLocaleError("", "", 0, locale.ErrInvalidValue)})
+	f(&Message{Type: Info, Message: "3"})
+	a.Equal(buf.String(), "23")
+}
@@ -0,0 +1,301 @@
+// SPDX-License-Identifier: MIT
+
+package output
+
+import (
+	"path"
+	"strings"
+
+	"github.com/issue9/version"
+
+	"github.com/caixw/apidoc/v6/doc"
+)
+
+// 根据版本号过滤文档中的内容
+type versionFilter struct {
+	version    string // 只保留 Version 小于等于此值的 API，为空表示不限制。
+	deprecated string // 去掉 Deprecated 小于等于此值的内容，为空表示不限制。
+	strip      bool   // 去掉 API 中所有标记为废弃的参数和枚举值
+}
+
+func newVersionFilter(o *Options) *versionFilter {
+	if o.Version == "" && o.Deprecated == "" && !o.StripDeprecated {
+		return nil
+	}
+
+	return &versionFilter{
+		version:    o.Version,
+		deprecated: o.Deprecated,
+		strip:      o.StripDeprecated,
+	}
+}
+
+func (f *versionFilter) filter(d *doc.Doc) {
+	if f.version != "" {
+		d.Version = doc.Version(f.version)
+	}
+
+	tags := make([]*doc.Tag, 0, len(d.Tags))
+	for _, tag := range d.Tags {
+		if !f.isDeprecated(tag.Deprecated, false) {
+			tags = append(tags, tag)
+		}
+	}
+	d.Tags = tags
+
+	srvs := make([]*doc.Server, 0, len(d.Servers))
+	for _, srv := range d.Servers {
+		if !f.isDeprecated(srv.Deprecated, false) {
+			srvs = append(srvs, srv)
+		}
+	}
+	d.Servers = srvs
+
+	apis := make([]*doc.API, 0, len(d.Apis))
+	for _, api := range d.Apis {
+		if f.api(d, api) {
+			apis = append(apis, api)
+		}
+	}
+	d.Apis = apis
+
+	d.Types = f.params(d.Types)
+	d.Responses = f.requests(d.Responses)
+}
+
+// 过滤 api 的内容，返回值表示是否保留该 api。
+func (f *versionFilter) api(d *doc.Doc, api *doc.API) bool {
+	if f.version != "" && api.Version != "" && compare(string(api.Version), f.version) > 0 {
+		return false
+	}
+
+	if f.isDeprecated(api.Deprecated, false) {
+		return false
+	}
+
+	// 所有的 server 都已经被去掉的 API，也不再保留。
+	servers := make([]string, 0, len(api.Servers))
+	for _, srv := range api.Servers {
+		if serverExists(d, srv) {
+			servers = append(servers, srv)
+		}
+	}
+	if len(api.Servers) > 0 && len(servers) == 0 {
+		return false
+	}
+	api.Servers = servers
+
+	tags := make([]string, 0, len(api.Tags))
+	for _, tag := range api.Tags {
+		if tagExists(d, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	api.Tags = tags
+
+	if api.Path != nil { // 路径参数由路径决定，不能去掉。
+		api.Path.Queries = f.params(api.Path.Queries)
+	}
+	api.Headers = f.params(api.Headers)
+	api.Cookies = f.params(api.Cookies)
+	api.Requests = f.requests(api.Requests)
+	api.Responses = f.requests(api.Responses)
+
+	if cb := api.Callback; cb != nil {
+		if f.isDeprecated(cb.Deprecated, true) {
+			api.Callback = nil
+		} else {
+			cb.Headers = f.params(cb.Headers)
+			cb.Cookies = f.params(cb.Cookies)
+			cb.Requests = f.requests(cb.Requests)
+			cb.Responses = f.requests(cb.Responses)
+		}
+	}
+
+	return true
+}
+
+func (f *versionFilter) requests(requests []*doc.Request) []*doc.Request {
+	if len(requests) == 0 {
+		return requests
+	}
+
+	rs := make([]*doc.Request, 0, len(requests))
+	for _, r := range requests {
+		if f.isDeprecated(r.Deprecated, true) {
+			continue
+		}
+
+		r.Items = f.params(r.Items)
+		r.Enums = f.enums(r.Enums)
+		r.Headers = f.params(r.Headers)
+		r.Cookies = f.params(r.Cookies)
+		f.union(r.OneOf)
+		f.union(r.AnyOf)
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+func (f *versionFilter) params(params []*doc.Param) []*doc.Param {
+	if len(params) == 0 {
+		return params
+	}
+
+	ps := make([]*doc.Param, 0, len(params))
+	for _, p := range params {
+		if f.isDeprecated(p.Deprecated, true) {
+			continue
+		}
+
+		p.Items = f.params(p.Items)
+		p.Enums = f.enums(p.Enums)
+		f.union(p.OneOf)
+		f.union(p.AnyOf)
+		ps = append(ps, p)
+	}
+	return ps
+}
+
+func (f *versionFilter) union(u *doc.Union) {
+	if u != nil {
+		u.Items = f.params(u.Items)
+	}
+}
+
+func (f *versionFilter) enums(enums []*doc.Enum) []*doc.Enum {
+	if len(enums) == 0 {
+		return enums
+	}
+
+	es := make([]*doc.Enum, 0, len(enums))
+	for _, e := range enums {
+		if !f.isDeprecated(e.Deprecated, true) {
+			es = append(es, e)
+		}
+	}
+	return es
+}
+
+// v 是否为需要去掉的废弃版本，inner 表示是否为 API 中的参数和枚举值等内容。
+func (f *versionFilter) isDeprecated(v doc.Version, inner bool) bool {
+	if v == "" {
+		return false
+	}
+
+	if inner && f.strip {
+		return true
+	}
+
+	return f.deprecated != "" && compare(string(v), f.deprecated) <= 0
+}
+
+// 比较两个版本号，doc.Version 和 Options 中的版本号都是经过验证的。
+func compare(v1, v2 string) int {
+	ret, err := version.SemVerCompare(v1, v2)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+func tagExists(d *doc.Doc, name string) bool {
+	for _, tag := range d.Tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func serverExists(d *doc.Doc, name string) bool {
+	for _, srv := range d.Servers {
+		if srv.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// 根据标签、服务和路径判断是否需要输出 api
+//
+// 同时会去掉 api 中不需要输出的标签和服务。
+func (o *Options) filterAPI(api *doc.API) bool {
+	if !o.contains(api.Tags...) || containsAny(o.ExcludeTags, api.Tags...) {
+		return false
+	}
+
+	if api.Path != nil {
+		if len(o.Paths) > 0 && !matchPaths(o.Paths, api.Path.Path) {
+			return false
+		}
+		if matchPaths(o.ExcludePaths, api.Path.Path) {
+			return false
+		}
+	}
+
+	srvs := make([]string, 0, len(api.Servers))
+	for _, srv := range api.Servers {
+		if (len(o.Servers) == 0 || containsAny(o.Servers, srv)) && !containsAny(o.ExcludeServers, srv) {
+			srvs = append(srvs, srv)
+		}
+	}
+	if len(srvs) == 0 && (len(api.Servers) > 0 || len(o.Servers) > 0) {
+		return false
+	}
+	api.Servers = srvs
+
+	if len(o.Tags) > 0 {
+		tags := make([]string, 0, len(api.Tags))
+		for _, tag := range api.Tags {
+			if containsAny(o.Tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		api.Tags = tags
+	}
+
+	return true
+}
+
+// list 中是否包含 vals 中的任意一个值
+func containsAny(list []string, vals ...string) bool {
+	for _, item := range list {
+		for _, v := range vals {
+			if item == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// p 是否匹配 patterns 中的任意一个模式
+func matchPaths(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if matchPath(pattern, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// 与 path.Match 相同，但是以 /** 结尾的模式可以匹配其下的所有路径。
+func matchPath(pattern, p string) bool {
+	base := strings.TrimSuffix(pattern, "/**")
+	if base == pattern {
+		matched, _ := path.Match(pattern, p)
+		return matched
+	}
+
+	if base == "" { // 以 /** 表示所有路径
+		return true
+	}
+
+	for i := len(p); i > 0; i = strings.LastIndexByte(p[:i], '/') {
+		if matched, _ := path.Match(base, p[:i]); matched {
+			return true
+		}
+	}
+	return false
+}
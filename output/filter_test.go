@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: MIT
+
+package output
+
+import (
+	"testing"
+
+	"github.com/issue9/assert"
+
+	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/doc/doctest"
+)
+
+const filterDocData = `<apidoc version="2.0.0">
+	<title>title</title>
+	<tag name="t1" title="t1" />
+	<tag name="t2" title="t2" deprecated="1.0.0" />
+	<server name="admin" url="https://example.com/admin" summary="admin" />
+	<server name="old" url="https://example.com/old" summary="old" deprecated="1.0.0" />
+	<mimetype>application/json</mimetype>
+	<api method="GET" summary="v1">
+		<path path="/users">
+			<query name="page" type="number" summary="page" />
+			<query name="size" type="number" summary="size" deprecated="1.5.0" />
+		</path>
+		<tag>t1</tag>
+		<tag>t2</tag>
+		<server>admin</server>
+		<response status="200" type="object" summary="summary">
+			<param name="sex" type="string" summary="sex">
+				<enum value="male" summary="male" />
+				<enum value="female" summary="female" />
+				<enum value="unknown" summary="unknown" deprecated="1.8.0" />
+			</param>
+			<param name="age" type="number" summary="age" deprecated="1.0.0" />
+		</response>
+	</api>
+	<api method="POST" summary="v2" version="2.0.0">
+		<path path="/users" />
+		<server>admin</server>
+		<response status="200" type="string" summary="summary" />
+	</api>
+	<api method="DELETE" summary="deprecated" deprecated="1.1.0">
+		<path path="/users" />
+		<server>admin</server>
+		<response status="200" type="string" summary="summary" />
+	</api>
+	<api method="PUT" summary="old server">
+		<path path="/users" />
+		<server>old</server>
+		<response status="200" type="string" summary="summary" />
+	</api>
+</apidoc>`
+
+func newFilterDoc(a *assert.Assertion) *doc.Doc {
+	d := doc.New()
+	a.NotError(d.FromXML("doc.xml", 1, []byte(filterDocData)))
+	a.NotError(d.Sanitize())
+	return d
+}
+
+func findAPI(d *doc.Doc, summary string) *doc.API {
+	for _, api := range d.Apis {
+		if api.Summary == summary {
+			return api
+		}
+	}
+	return nil
+}
+
+func TestFilterDoc_version(t *testing.T) {
+	a := assert.New(t)
+
+	d := newFilterDoc(a)
+	filterDoc(d, &Options{})
+	a.Equal(4, len(d.Apis)).Equal(2, len(d.Tags))
+
+	d = newFilterDoc(a)
+	filterDoc(d, &Options{Version: "1.9.0"})
+	a.Equal(3, len(d.Apis)).Equal(d.Version, "1.9.0")
+
+	d = newFilterDoc(a)
+	filterDoc(d, &Options{Deprecated: "1.5.0"})
+	// DELETE 已废弃，PUT 的 server 已废弃
+	a.Equal(2, len(d.Apis)).
+		Equal(1, len(d.Tags)).
+		Equal(1, len(d.Servers))
+	api := findAPI(d, "v1")
+	a.NotNil(api).
+		Equal(api.Tags, []string{"t1"}).
+		Equal(1, len(api.Path.Queries))
+	resp := api.Responses[0]
+	a.Equal(1, len(resp.Items)).
+		Equal(3, len(resp.Items[0].Enums))
+
+	d = newFilterDoc(a)
+	filterDoc(d, &Options{StripDeprecated: true})
+	a.Equal(4, len(d.Apis))
+	api = findAPI(d, "v1")
+	a.NotNil(api).
+		Equal(1, len(api.Path.Queries)).
+		Equal(2, len(api.Responses[0].Items[0].Enums))
+}
+
+func TestVersionFilter_isDeprecated(t *testing.T) {
+	a := assert.New(t)
+
+	f := &versionFilter{deprecated: "1.0.0"}
+	a.False(f.isDeprecated("", false)).
+		True(f.isDeprecated("0.1.0", false)).
+		True(f.isDeprecated("1.0.0", false)).
+		False(f.isDeprecated("1.0.1", true))
+
+	f.strip = true
+	a.True(f.isDeprecated("1.0.1", true)).
+		False(f.isDeprecated("1.0.1", false))
+}
+
+func TestFilterDoc_servers(t *testing.T) {
+	a := assert.New(t)
+
+	d := doctest.Get()
+	filterDoc(d, &Options{Servers: []string{"client"}})
+	a.Equal(1, len(d.Apis)).
+		Equal(1, len(d.Servers)).
+		Equal(d.Servers[0].Name, "client").
+		Equal(d.Apis[0].Servers, []string{"client"}).
+		Equal(2, len(d.Tags)) // 只保留被引用的标签
+
+	d = doctest.Get()
+	filterDoc(d, &Options{ExcludeServers: []string{"admin"}})
+	a.Equal(1, len(d.Apis)).
+		Equal(1, len(d.Servers)).
+		Equal(d.Apis[0].Servers, []string{"client"})
+
+	d = doctest.Get()
+	filterDoc(d, &Options{ExcludeTags: []string{"tag1"}})
+	a.Equal(1, len(d.Apis)).
+		Equal(2, len(d.Tags)).
+		Equal(2, len(d.Servers))
+
+	d = doctest.Get()
+	filterDoc(d, &Options{Tags: []string{"t1"}, ExcludeServers: []string{"client"}})
+	a.Equal(2, len(d.Apis)).
+		Equal(1, len(d.Tags)).
+		Equal(1, len(d.Servers)).
+		Equal(d.Apis[0].Tags, []string{"t1"})
+}
+
+func TestFilterDoc_paths(t *testing.T) {
+	a := assert.New(t)
+
+	d := doctest.Get()
+	filterDoc(d, &Options{Paths: []string{"/users/**"}})
+	a.Equal(2, len(d.Apis))
+
+	d = doctest.Get()
+	filterDoc(d, &Options{ExcludePaths: []string{"/users"}})
+	a.Equal(0, len(d.Apis)).
+		Equal(0, len(d.Tags)).
+		Equal(0, len(d.Servers))
+}
+
+func TestMatchPath(t *testing.T) {
+	a := assert.New(t)
+
+	a.True(matchPath("/users", "/users"))
+	a.True(matchPath("/users/*", "/users/{id}"))
+	a.False(matchPath("/users/*", "/users/{id}/groups"))
+	a.True(matchPath("/users/**", "/users"))
+	a.True(matchPath("/users/**", "/users/{id}/groups"))
+	a.False(matchPath("/users/**", "/users-groups"))
+	a.True(matchPath("/*/groups/**", "/users/groups/1"))
+	a.True(matchPath("/**", "/users"))
+}
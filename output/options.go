@@ -4,11 +4,18 @@ package output
 
 import (
 	"encoding/xml"
+	"path"
+	"strconv"
 	"strings"
 
+	"github.com/issue9/version"
+
 	"github.com/caixw/apidoc/v6/doc"
+	"github.com/caixw/apidoc/v6/internal/html"
 	"github.com/caixw/apidoc/v6/internal/locale"
+	"github.com/caixw/apidoc/v6/internal/markdown"
 	"github.com/caixw/apidoc/v6/internal/openapi"
+	"github.com/caixw/apidoc/v6/internal/postman"
 	"github.com/caixw/apidoc/v6/internal/vars"
 	"github.com/caixw/apidoc/v6/message"
 )
@@ -18,6 +25,9 @@ const (
 	ApidocXML   = "apidoc+xml"
 	OpenapiYAML = "openapi+yaml"
 	OpenapiJSON = "openapi+json"
+	Markdown    = "markdown"
+	HTML        = "html"
+	PostmanJSON = "postman+json"
 )
 
 var stylesheetURL string
@@ -39,6 +49,43 @@ type Options struct {
 	// 只输出该标签的文档，若为空，则表示所有。
 	Tags []string `yaml:"tags,omitempty"`
 
+	// 不输出带有这些标签的文档
+	ExcludeTags []string `yaml:"exclude-tags,omitempty"`
+
+	// 只输出该服务的文档，若为空，则表示所有。
+	//
+	// API 中的其它服务也会被去掉，不会出现在输出的文档中。
+	Servers []string `yaml:"servers,omitempty"`
+
+	// 不输出这些服务的文档
+	//
+	// 同时属于其它服务的 API 依然会被输出，但不再包含这些服务。
+	ExcludeServers []string `yaml:"exclude-servers,omitempty"`
+
+	// 只输出路径匹配这些模式的文档，若为空，则表示所有。
+	//
+	// 模式的语法与 path.Match 相同，另外以 /** 结尾的表示匹配其下的所有路径，
+	// 比如 /admin/** 可以匹配 /admin 和 /admin/users/{id} 等。
+	Paths []string `yaml:"paths,omitempty"`
+
+	// 不输出路径匹配这些模式的文档，语法与 Paths 相同。
+	ExcludePaths []string `yaml:"exclude-paths,omitempty"`
+
+	// 只输出在该版本及之前添加的 API，即 API.Version 小于等于该值的，
+	// 未指定 version 的 API 总是会被输出。同时也会作为输出文档的版本号。
+	//
+	// 若为空，则表示所有。
+	Version string `yaml:"version,omitempty"`
+
+	// 去掉在该版本及之前就已经废弃的内容
+	//
+	// 包括 deprecated 小于等于该值的 API、标签、服务以及 API 中的参数和枚举值等，
+	// 若为空，则不作过滤。
+	Deprecated string `yaml:"deprecated,omitempty"`
+
+	// 去掉 API 中所有标记为废弃的参数和枚举值，而不管其废弃的版本号。
+	StripDeprecated bool `yaml:"strip-deprecated,omitempty"`
+
 	// xslt 文件地址
 	//
 	// 默认值为 https://apidoc.tools/docs/ 下当前版本的 apidoc.xsl，比如：
@@ -82,10 +129,36 @@ func (o *Options) sanitize(buf bool) *message.SyntaxError {
 		o.marshal = openapi.JSON
 	case OpenapiYAML:
 		o.marshal = openapi.YAML
+	case Markdown:
+		o.marshal = markdown.Marshal
+	case HTML:
+		o.marshal = html.Marshal
+	case PostmanJSON:
+		o.marshal = postman.JSON
 	default:
 		return message.NewLocaleError("", "type", 0, locale.ErrInvalidValue)
 	}
 
+	for index, pattern := range o.Paths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return message.WithError("", "paths["+strconv.Itoa(index)+"]", 0, err)
+		}
+	}
+
+	for index, pattern := range o.ExcludePaths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return message.WithError("", "exclude-paths["+strconv.Itoa(index)+"]", 0, err)
+		}
+	}
+
+	if o.Version != "" && !version.SemVerValid(o.Version) {
+		return message.NewLocaleError("", "version", 0, locale.ErrInvalidFormat)
+	}
+
+	if o.Deprecated != "" && !version.SemVerValid(o.Deprecated) {
+		return message.NewLocaleError("", "deprecated", 0, locale.ErrInvalidFormat)
+	}
+
 	o.xml = strings.HasSuffix(o.Type, "+xml")
 
 	if o.Path == "" && !buf {
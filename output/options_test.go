@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/issue9/assert"
+	"gopkg.in/yaml.v2"
 )
 
 func TestStylesheet(t *testing.T) {
@@ -13,6 +14,20 @@ func TestStylesheet(t *testing.T) {
 	a.NotEmpty(stylesheetURL)
 }
 
+func TestOptions_yaml(t *testing.T) {
+	a := assert.New(t)
+
+	o := &Options{}
+	a.NotError(yaml.Unmarshal([]byte(`exclude-tags: [t1]
+exclude-servers: [admin]
+exclude-paths: [/admin/**]
+strip-deprecated: true`), o))
+	a.Equal(o.ExcludeTags, []string{"t1"}).
+		Equal(o.ExcludeServers, []string{"admin"}).
+		Equal(o.ExcludePaths, []string{"/admin/**"}).
+		True(o.StripDeprecated)
+}
+
 func TestOptions_contains(t *testing.T) {
 	a := assert.New(t)
 
@@ -43,6 +58,33 @@ func TestOptions_sanitize(t *testing.T) {
 	o = &Options{}
 	a.NotError(o.sanitize(true))
 
+	o = &Options{Type: Markdown}
+	a.NotError(o.sanitize(true))
+	a.False(o.xml).Empty(o.procInst)
+
+	o = &Options{Type: HTML}
+	a.NotError(o.sanitize(true))
+	a.False(o.xml).Empty(o.procInst)
+
+	o = &Options{Type: PostmanJSON}
+	a.NotError(o.sanitize(true))
+	a.False(o.xml).Empty(o.procInst)
+
+	o = &Options{Paths: []string{"/users/["}}
+	a.Error(o.sanitize(true))
+
+	o = &Options{ExcludePaths: []string{"/users/["}}
+	a.Error(o.sanitize(true))
+
+	o = &Options{Version: "1.0"}
+	a.Error(o.sanitize(true))
+
+	o = &Options{Deprecated: "1.0"}
+	a.Error(o.sanitize(true))
+
+	o = &Options{Version: "1.0.0", Deprecated: "1.0.0"}
+	a.NotError(o.sanitize(true))
+
 	o.Path = "./testdir/apidoc.json"
 	a.NotError(o.sanitize(false))
 	a.Equal(o.Style, stylesheetURL).
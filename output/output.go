@@ -63,23 +63,49 @@ func buffer(d *doc.Doc, opt *Options) (*bytes.Buffer, error) {
 }
 
 func filterDoc(d *doc.Doc, o *Options) {
-	if len(o.Tags) == 0 {
-		return
+	if f := newVersionFilter(o); f != nil {
+		f.filter(d)
 	}
 
-	tags := make([]*doc.Tag, 0, len(o.Tags))
-	for _, tag := range d.Tags {
-		if o.contains(tag.Name) {
-			tags = append(tags, tag)
-		}
+	if len(o.Tags) == 0 && len(o.ExcludeTags) == 0 &&
+		len(o.Servers) == 0 && len(o.ExcludeServers) == 0 &&
+		len(o.Paths) == 0 && len(o.ExcludePaths) == 0 {
+		return
 	}
-	d.Tags = tags
+
+	// 输出的文档中只保留被 API 引用的标签和服务
+	tags := make(map[string]bool, len(d.Tags))
+	servers := make(map[string]bool, len(d.Servers))
 
 	apis := make([]*doc.API, 0, len(d.Apis))
 	for _, api := range d.Apis {
-		if o.contains(api.Tags...) {
-			apis = append(apis, api)
+		if !o.filterAPI(api) {
+			continue
+		}
+
+		for _, tag := range api.Tags {
+			tags[tag] = true
+		}
+		for _, srv := range api.Servers {
+			servers[srv] = true
 		}
+		apis = append(apis, api)
 	}
 	d.Apis = apis
+
+	ts := make([]*doc.Tag, 0, len(tags))
+	for _, tag := range d.Tags {
+		if tags[tag.Name] {
+			ts = append(ts, tag)
+		}
+	}
+	d.Tags = ts
+
+	srvs := make([]*doc.Server, 0, len(servers))
+	for _, srv := range d.Servers {
+		if servers[srv.Name] {
+			srvs = append(srvs, srv)
+		}
+	}
+	d.Servers = srvs
 }